@@ -0,0 +1,153 @@
+package gohltb
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// SearchAPI selects which of howlongtobeat.com's endpoints are used to run
+// game and user searches. Both produce the same GameResultsPage and
+// UserResultsPage results.
+type SearchAPI int
+
+const (
+	// HTMLSearchAPI scrapes the HTML search results page. This is the default.
+	HTMLSearchAPI SearchAPI = iota
+	// JSONSearchAPI uses the JSON search endpoint used by the site's frontend
+	JSONSearchAPI
+)
+
+// Backend is the source of the data returned by a HLTBClient. By default the
+// client uses a backend that makes requests to howlongtobeat.com, selected by
+// SearchAPI. Setting HLTBClient.Backend allows the data to come from somewhere
+// else instead, such as a mirror, a cache service, an offline dataset or a fake
+// in tests.
+//
+// Queries passed to SearchGames and SearchUsers have already had their
+// defaults applied. The client takes care of linking the returned pages back
+// to itself, so GetNextPage works regardless of the backend.
+type Backend interface {
+	SearchGames(ctx context.Context, q *HLTBQuery) (*GameResultsPage, error)
+	SearchUsers(ctx context.Context, q *HLTBQuery) (*UserResultsPage, error)
+	GetGame(ctx context.Context, id string) (*GameDetail, error)
+	GetUser(ctx context.Context, name string) (*UserProfile, error)
+	GetUserGames(ctx context.Context, name string, list UserList, page int) (*UserGamesPage, error)
+}
+
+// backend returns the client's Backend if one is set, otherwise the built in
+// backend selected by the client's SearchAPI
+func (h *HLTBClient) backend() Backend {
+	if h.Backend != nil {
+		return h.Backend
+	}
+	if h.SearchAPI == JSONSearchAPI {
+		return jsonBackend{htmlBackend{h}}
+	}
+	return htmlBackend{h}
+}
+
+// htmlBackend runs searches by posting a form to /search_results and scraping
+// the returned HTML. Details are scraped from the game and user pages.
+type htmlBackend struct {
+	h *HLTBClient
+}
+
+// SearchGames runs a game search against the HTML endpoint
+func (b htmlBackend) SearchGames(ctx context.Context, q *HLTBQuery) (*GameResultsPage, error) {
+	sel := b.h.selectors()
+	var games *GameResultsPage
+	err := searchQuery(ctx, b.h, q, func(doc *goquery.Document) error {
+		found, err := hasResults(doc, q.Page, sel)
+		if err != nil {
+			return err
+		}
+		if !found {
+			games = &GameResultsPage{}
+			return nil
+		}
+		if err := validateLayout(doc, q.Page, sel); err != nil {
+			return err
+		}
+		if games, err = parseGameResponse(doc, q, sel); err != nil {
+			return err
+		}
+		return validateGameTimes(doc, q.Page, sel)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return games, nil
+}
+
+// SearchUsers runs a user search against the HTML endpoint
+func (b htmlBackend) SearchUsers(ctx context.Context, q *HLTBQuery) (*UserResultsPage, error) {
+	sel := b.h.selectors()
+	var users *UserResultsPage
+	err := searchQuery(ctx, b.h, q, func(doc *goquery.Document) error {
+		found, err := hasResults(doc, q.Page, sel)
+		if err != nil {
+			return err
+		}
+		if !found {
+			users = &UserResultsPage{}
+			return nil
+		}
+		if err := validateLayout(doc, q.Page, sel); err != nil {
+			return err
+		}
+		users, err = parseUserResponse(doc, q, sel)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// GetGame scrapes the game's page
+func (b htmlBackend) GetGame(ctx context.Context, id string) (*GameDetail, error) {
+	endpoint := fmt.Sprintf("%v/game?id=%v", b.h.Client.baseURL, url.QueryEscape(id))
+	var game *GameDetail
+	err := fetchDocument(ctx, b.h, "GET", endpoint, "", func(doc *goquery.Document) error {
+		var err error
+		game, err = parseGameDetail(doc, id, b.h.selectors())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+// GetUser scrapes the user's profile page
+func (b htmlBackend) GetUser(ctx context.Context, name string) (*UserProfile, error) {
+	endpoint := fmt.Sprintf("%v/user?n=%v", b.h.Client.baseURL, url.QueryEscape(name))
+	var user *UserProfile
+	err := fetchDocument(ctx, b.h, "GET", endpoint, "", func(doc *goquery.Document) error {
+		var err error
+		user, err = parseUserProfile(doc, name, b.h.selectors())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// GetUserGames scrapes a page of one of the user's lists
+func (b htmlBackend) GetUserGames(ctx context.Context, name string, list UserList, page int) (*UserGamesPage, error) {
+	endpoint := fmt.Sprintf("%v/user_games?n=%v&s=%v&page=%v", b.h.Client.baseURL, url.QueryEscape(name), url.QueryEscape(string(list)), page)
+	var games *UserGamesPage
+	err := fetchDocument(ctx, b.h, "GET", endpoint, "", func(doc *goquery.Document) error {
+		var err error
+		games, err = parseUserGames(doc, page, b.h.selectors())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return games, nil
+}
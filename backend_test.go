@@ -0,0 +1,154 @@
+package gohltb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type fakeBackend struct {
+	queries []HLTBQuery
+}
+
+func (f *fakeBackend) SearchGames(ctx context.Context, q *HLTBQuery) (*GameResultsPage, error) {
+	f.queries = append(f.queries, *q)
+	res := &GameResultsPage{
+		Games:       []*GameResult{{ID: fmt.Sprint(q.Page), Title: q.Query}},
+		TotalPages:  2,
+		CurrentPage: q.Page,
+	}
+	if q.Page < 2 {
+		res.NextPage = q.Page + 1
+	}
+	return res, nil
+}
+
+func (f *fakeBackend) SearchUsers(ctx context.Context, q *HLTBQuery) (*UserResultsPage, error) {
+	f.queries = append(f.queries, *q)
+	return &UserResultsPage{Users: []*UserResult{{ID: q.Query}}, TotalPages: 1, CurrentPage: q.Page}, nil
+}
+
+func (f *fakeBackend) GetGame(ctx context.Context, id string) (*GameDetail, error) {
+	return &GameDetail{ID: id, TimeStats: &GameTimeStats{Main: &TimeStats{Polled: 1}}}, nil
+}
+
+func (f *fakeBackend) GetUser(ctx context.Context, name string) (*UserProfile, error) {
+	return nil, errors.New("offline")
+}
+
+func (f *fakeBackend) GetUserGames(ctx context.Context, name string, list UserList, page int) (*UserGamesPage, error) {
+	return &UserGamesPage{Games: []*UserGameEntry{{GameID: "1"}}, TotalPages: 3, NextPage: page + 1}, nil
+}
+
+func TestBackendSearch(t *testing.T) {
+	fake := &fakeBackend{}
+	client := NewDefaultClient()
+	client.Backend = fake
+
+	res, err := client.SearchGames("Doom")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(fake.queries) != 1 || fake.queries[0].SortBy != SortByGameName || fake.queries[0].Page != 1 {
+		fmt.Printf("Got %+v, expected query with defaults", fake.queries)
+		t.Fail()
+	}
+	res, err = res.GetNextPage()
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if res.Games[0].ID != "2" || res.Games[0].Title != "Doom" || res.HasNext() {
+		fmt.Printf("Got %+v, expected the second page", res.Games[0])
+		t.Fail()
+	}
+
+	users, err := client.SearchUsers("Bob")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if users.Users[0].ID != "Bob" || fake.queries[2].QueryType != UserQuery {
+		fmt.Printf("Got %+v, unexpected user", users.Users[0])
+		t.Fail()
+	}
+}
+
+func TestBackendDetails(t *testing.T) {
+	client := NewDefaultClient()
+	client.Backend = &fakeBackend{}
+
+	stats, err := client.GetGameTimeStats(context.Background(), "57506")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if stats.Main.Polled != 1 {
+		fmt.Printf("Got %+v, expected stats from backend", stats)
+		t.Fail()
+	}
+	if _, err := client.GetUser(context.Background(), "tiamat911"); err == nil || err.Error() != "offline" {
+		fmt.Printf("Got %v, expected backend error", err)
+		t.Fail()
+	}
+	games, err := client.GetUserGames(context.Background(), "tiamat911", ListBacklog, 2)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if games.User != "tiamat911" || games.List != ListBacklog || games.CurrentPage != 2 || !games.HasNext() {
+		fmt.Printf("Got %+v, expected page linked to the request", games)
+		t.Fail()
+	}
+}
+
+type nilBackend struct {
+	fakeBackend
+}
+
+func (n *nilBackend) SearchGames(ctx context.Context, q *HLTBQuery) (*GameResultsPage, error) {
+	return nil, nil
+}
+
+func (n *nilBackend) SearchUsers(ctx context.Context, q *HLTBQuery) (*UserResultsPage, error) {
+	return nil, nil
+}
+
+func (n *nilBackend) GetUserGames(ctx context.Context, name string, list UserList, page int) (*UserGamesPage, error) {
+	return nil, nil
+}
+
+func (n *nilBackend) GetGame(ctx context.Context, id string) (*GameDetail, error) {
+	return nil, nil
+}
+
+func (n *nilBackend) GetUser(ctx context.Context, name string) (*UserProfile, error) {
+	return nil, nil
+}
+
+func TestBackendNilPage(t *testing.T) {
+	client := NewDefaultClient()
+	client.Backend = &nilBackend{}
+
+	if _, err := client.SearchGames("Doom"); !errors.Is(err, ErrNilPage) {
+		fmt.Printf("Got %v, expected ErrNilPage", err)
+		t.Fail()
+	}
+	if _, err := client.SearchUsers("tiamat"); !errors.Is(err, ErrNilPage) {
+		fmt.Printf("Got %v, expected ErrNilPage", err)
+		t.Fail()
+	}
+	if _, err := client.GetUserGames(context.Background(), "tiamat", ListBacklog, 1); !errors.Is(err, ErrNilPage) {
+		fmt.Printf("Got %v, expected ErrNilPage", err)
+		t.Fail()
+	}
+	if _, err := client.GetGame(context.Background(), "57506"); !errors.Is(err, ErrNilPage) {
+		fmt.Printf("Got %v, expected ErrNilPage", err)
+		t.Fail()
+	}
+	if _, err := client.GetGameTimeStats(context.Background(), "57506"); !errors.Is(err, ErrNilPage) {
+		fmt.Printf("Got %v, expected ErrNilPage", err)
+		t.Fail()
+	}
+	if _, err := client.GetUser(context.Background(), "tiamat"); !errors.Is(err, ErrNilPage) {
+		fmt.Printf("Got %v, expected ErrNilPage", err)
+		t.Fail()
+	}
+}
@@ -0,0 +1,125 @@
+package gohltb
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Cache stores the raw responses from howlongtobeat.com so that repeated queries
+// can be served without making a request. Keys are built from the request URL and
+// the form sent to the server. Implementations must be safe for concurrent use.
+//
+// Two implementations are provided: MemoryCache, which lives for the life of the
+// process, and FileCache, which persists responses to disk between runs.
+type Cache interface {
+	// Get returns the value stored for key, if it exists and has not expired
+	Get(key string) ([]byte, bool)
+	// Set stores value for key. Failures to store a value are not reported,
+	// the response will simply be requested again next time.
+	Set(key string, value []byte)
+}
+
+// CacheStats are the hit and miss counters of a cache
+type CacheStats struct {
+	Hits    uint64 // Number of lookups served from the cache
+	Misses  uint64 // Number of lookups not found in the cache, or expired
+	Entries int    // Number of entries currently held
+}
+
+// MemoryCache is an in-memory cache of responses from howlongtobeat.com. Entries
+// expire after the cache's TTL, and once the cache is full the least recently
+// used entry is evicted. A MemoryCache is safe to share between goroutines.
+//
+// Responses are keyed by the form sent to the server and the page number, so
+// identical queries made within the TTL will be served without a request.
+//
+// example:
+//
+//	client := gohltb.NewDefaultClient()
+//	client.Cache = gohltb.NewMemoryCache(500, 10*time.Minute)
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	order      *list.List               // most recently used at the front
+	entries    map[string]*list.Element // key to element in order
+	hits       uint64
+	misses     uint64
+}
+
+// cacheEntry is a single value held by MemoryCache
+type cacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache creates a MemoryCache holding up to maxEntries responses, each
+// of which expires after ttl. A maxEntries of 0 means there is no limit on the
+// number of entries, and a ttl of 0 means entries never expire.
+func NewMemoryCache(maxEntries int, ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the value stored for key, if it exists and has not expired
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		c.removeElement(el)
+		c.misses++
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	c.hits++
+	return entry.value, true
+}
+
+// Set stores value for key, evicting the least recently used entry if the
+// cache is full.
+func (c *MemoryCache) Set(key string, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = time.Now().Add(c.ttl)
+	}
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*cacheEntry)
+		entry.value = value
+		entry.expires = expires
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: value, expires: expires})
+	if c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// Stats returns the current hit and miss counters
+func (c *MemoryCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{Hits: c.hits, Misses: c.misses, Entries: c.order.Len()}
+}
+
+// removeElement drops an element from the cache. Must be called with mu held.
+func (c *MemoryCache) removeElement(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*cacheEntry).key)
+}
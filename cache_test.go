@@ -0,0 +1,121 @@
+package gohltb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheEviction(t *testing.T) {
+	cache := NewMemoryCache(2, 0)
+	cache.Set("a", []byte("1"))
+	cache.Set("b", []byte("2"))
+	cache.Get("a")
+	cache.Set("c", []byte("3"))
+
+	if _, ok := cache.Get("b"); ok {
+		fmt.Println("Expected least recently used entry to be evicted")
+		t.Fail()
+	}
+	if v, ok := cache.Get("a"); !ok || string(v) != "1" {
+		fmt.Printf("Got %v, expected 1", string(v))
+		t.Fail()
+	}
+	stats := cache.Stats()
+	if stats.Hits != 2 || stats.Misses != 1 || stats.Entries != 2 {
+		fmt.Printf("Got %+v, expected 2 hits, 1 miss, 2 entries", stats)
+		t.Fail()
+	}
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+	cache := NewMemoryCache(0, 10*time.Millisecond)
+	cache.Set("a", []byte("1"))
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := cache.Get("a"); ok {
+		fmt.Println("Expected entry to have expired")
+		t.Fail()
+	}
+	if cache.Stats().Entries != 0 {
+		fmt.Printf("Got %v, expected 0", cache.Stats().Entries)
+		t.Fail()
+	}
+}
+
+func TestCachedSearch(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/games/basic_response.html")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintln(w, string(data))
+	}))
+	defer ts.Close()
+
+	client := NewCustomClient(&HTTPClient{baseURL: ts.URL})
+	cache := NewMemoryCache(10, time.Minute)
+	client.Cache = cache
+
+	for i := 0; i < 3; i++ {
+		res, err := client.SearchGames("pokemon red")
+		if err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
+		if len(res.Games) != 2 {
+			fmt.Printf("Got %v, expected 2", len(res.Games))
+			t.Fail()
+		}
+	}
+	if _, err := client.SearchGames("pokemon blue"); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if requests != 2 {
+		fmt.Printf("Got %v, expected 2", requests)
+		t.Fail()
+	}
+	if hits := cache.Stats().Hits; hits != 2 {
+		fmt.Printf("Got %v, expected 2", hits)
+		t.Fail()
+	}
+}
+
+func TestUnparsedResponseNotCached(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/games/basic_response.html")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			fmt.Fprintln(w, "<html><body><p>Down for maintenance</p></body></html>")
+			return
+		}
+		fmt.Fprintln(w, string(data))
+	}))
+	defer ts.Close()
+
+	client := NewCustomClient(&HTTPClient{baseURL: ts.URL})
+	client.Cache = NewMemoryCache(10, time.Minute)
+
+	if _, err := client.SearchGames("pokemon red"); err == nil {
+		t.Fatal("Expected maintenance page to return an error")
+	}
+	res, err := client.SearchGames("pokemon red")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(res.Games) != 2 {
+		fmt.Printf("Got %v, expected 2", len(res.Games))
+		t.Fail()
+	}
+	if requests != 2 {
+		fmt.Printf("Got %v, expected 2", requests)
+		t.Fail()
+	}
+}
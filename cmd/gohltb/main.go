@@ -1,9 +1,11 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/fuzzylimes/gohltb"
 )
@@ -13,6 +15,8 @@ var sortBy string
 var details bool
 var user bool
 var randomGame bool
+var cacheDir string
+var cacheTTL time.Duration
 
 func init() {
 	flag.StringVar(&query, "q", "", "Query string. This will be the game title if searching for games, or user name if searching for users.")
@@ -20,6 +24,8 @@ func init() {
 	flag.StringVar(&sortBy, "s", "name", "How the response should be sorted. Sorts by name by default.\nGames support: name, main, mainp, comp, averagea, rating, popular, backlog, usersp, playing, speedruns, release\nUsers support: name, gender, postcount, numcomp, numbacklog")
 	flag.BoolVar(&details, "d", false, "Include additional user details when querying games.")
 	flag.BoolVar(&randomGame, "r", false, "Return a single, random, game or user.")
+	flag.StringVar(&cacheDir, "c", "", "Directory used to cache responses between runs. Caching is disabled when empty.")
+	flag.DurationVar(&cacheTTL, "t", time.Hour, "How long cached responses are kept for (only used with -c).")
 	flag.Parse()
 }
 
@@ -49,11 +55,18 @@ func main() {
 	}
 
 	client := gohltb.NewDefaultClient()
+	if cacheDir != "" {
+		cache, err := gohltb.NewFileCache(cacheDir, cacheTTL, 50<<20)
+		if err != nil {
+			log.Fatal(err)
+		}
+		client.Cache = cache
+	}
 
 	if user {
 		games, err := client.SearchUsersByQuery(q)
 		if err != nil {
-			log.Fatal(err)
+			handleError(err)
 		}
 
 		j, err := games.JSON()
@@ -64,7 +77,7 @@ func main() {
 	} else {
 		games, err := client.SearchGamesByQuery(q)
 		if err != nil {
-			log.Fatal(err)
+			handleError(err)
 		}
 
 		j, err := games.JSON()
@@ -76,6 +89,21 @@ func main() {
 
 }
 
+// handleError reports a query error in a more readable form and exits
+func handleError(err error) {
+	var statusErr *gohltb.HTTPStatusError
+	var parseErr *gohltb.ParseError
+	switch {
+	case errors.As(err, &statusErr):
+		log.Fatalf("howlongtobeat.com responded with status %v, try again later", statusErr.StatusCode)
+	case errors.Is(err, gohltb.ErrLayoutChanged):
+		log.Fatalf("The layout of howlongtobeat.com has changed and can no longer be read: %v", err)
+	case errors.As(err, &parseErr):
+		log.Fatalf("Unable to read response from howlongtobeat.com, the site may have changed: %v", parseErr)
+	}
+	log.Fatal(err)
+}
+
 func isValidSort(sort string, t gohltb.QueryType) bool {
 	games := []string{"name", "main", "mainp", "comp", "averagea", "rating", "popular", "backlog", "usersp", "playing", "speedruns", "release"}
 	users := []string{"name", "gender", "postcount", "numcomp", "numbacklog"}
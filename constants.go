@@ -1,5 +1,7 @@
 package gohltb
 
+import "strings"
+
 // Platform is where the game is played (i.e. console, operating system)
 type Platform string
 
@@ -18,6 +20,111 @@ type SortDirection string
 // QueryType is the subject being queried
 type QueryType string
 
+// Platforms is every Platform supported by howlongtobeat.com
+var Platforms = []Platform{
+	ThreeDO,
+	Amiga,
+	AmstradCPC,
+	Android,
+	AppleII,
+	Arcade,
+	Atari2600,
+	Atari5200,
+	Atari7800,
+	Atari8bitFamily,
+	AtariJaguar,
+	AtariJaguarCD,
+	AtariLynx,
+	AtariST,
+	BBCMicro,
+	Browser,
+	ColecoVision,
+	Commodore64,
+	Dreamcast,
+	Emulated,
+	FMTowns,
+	GameWatch,
+	GameBoy,
+	GameBoyAdvance,
+	GameBoyColor,
+	GearVR,
+	GoogleStadia,
+	Intellivision,
+	InteractiveMovie,
+	iOS,
+	Linux,
+	Mac,
+	Mobile,
+	MSX,
+	NGage,
+	NECPC8800,
+	NECPC980121,
+	NECPCFX,
+	NeoGeo,
+	NeoGeoCD,
+	NeoGeoPocket,
+	NES,
+	Nintendo3DS,
+	Nintendo64,
+	NintendoDS,
+	NintendoGameCube,
+	NintendoSwitch,
+	OculusGo,
+	OculusQuest,
+	OnLive,
+	Ouya,
+	PC,
+	PCVR,
+	PhilipsCDi,
+	PhilipsVideopacG7000,
+	PlayStation,
+	PlayStation2,
+	PlayStation3,
+	PlayStation4,
+	PlayStation5,
+	PlayStationMobile,
+	PlayStationNow,
+	PlayStationPortable,
+	PlayStationVita,
+	PlayStationVR,
+	PlugPlay,
+	Sega32X,
+	SegaCD,
+	SegaGameGear,
+	SegaMasterSystem,
+	SegaMegaDriveGenesis,
+	SegaSaturn,
+	SG1000,
+	SharpX68000,
+	SuperNintendo,
+	TigerHandheld,
+	TurboGrafx16,
+	TurboGrafxCD,
+	VirtualBoy,
+	Wii,
+	WiiU,
+	WindowsPhone,
+	WonderSwan,
+	Xbox,
+	Xbox360,
+	XboxOne,
+	XboxSeriesXS,
+	ZXSpectrum,
+}
+
+// ParsePlatform finds the Platform matching a platform name as displayed on
+// howlongtobeat.com. The match is not case sensitive. If the name does not match
+// a known Platform, the name is returned as a Platform along with false.
+func ParsePlatform(name string) (Platform, bool) {
+	name = strings.TrimSpace(name)
+	for _, p := range Platforms {
+		if strings.EqualFold(string(p), name) {
+			return p, true
+		}
+	}
+	return Platform(name), false
+}
+
 const (
 	// Platforms
 
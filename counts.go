@@ -0,0 +1,132 @@
+package gohltb
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxInt is the largest value of an int on the current platform
+const maxInt = int(^uint(0) >> 1)
+
+// countRegex matches count strings such as "919", "5.3K" or "1.2M", once any
+// thousands separators have been removed
+var countRegex = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)([KkMm]?)$`)
+
+// ratingRegex matches rating strings such as "85%" or "87% by 590"
+var ratingRegex = regexp.MustCompile(`^([0-9.]+)%(?:\s+by\s+([0-9.,]+[KkMm]?))?$`)
+
+// ParseCount converts an abbreviated count as displayed on howlongtobeat.com
+// into an int. Counts above one thousand are displayed by the site with a K or M
+// suffix and a single decimal place (i.e. "5.3K"), so the returned value is only
+// accurate to the displayed precision: "5.3K" will return 5300 while the real
+// count may be anywhere from 5250 to 5349.
+func ParseCount(s string) (int, error) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+	m := countRegex.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("unable to parse count %q", s)
+	}
+
+	multiplier := 1.0
+	switch m[2] {
+	case "K", "k":
+		multiplier = 1e3
+	case "M", "m":
+		multiplier = 1e6
+	}
+
+	f, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse count %q", s)
+	}
+	f = math.Round(f * multiplier)
+	if f >= float64(maxInt) {
+		return 0, fmt.Errorf("count %q is out of range", s)
+	}
+	return int(f), nil
+}
+
+// ParseRating converts a rating as displayed on howlongtobeat.com (i.e. "85%"
+// or "87% by 590") into its percentage and, when present, the number of users
+// that submitted a rating. The number of ratings is subject to the same loss of
+// precision as ParseCount, and is 0 when not displayed.
+func ParseRating(s string) (float64, int, error) {
+	s = strings.TrimSpace(s)
+	m := ratingRegex.FindStringSubmatch(s)
+	if m == nil {
+		return 0, 0, fmt.Errorf("unable to parse rating %q", s)
+	}
+	percent, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to parse rating %q", s)
+	}
+	var votes int
+	if m[2] != "" {
+		if votes, err = ParseCount(m[2]); err != nil {
+			return 0, 0, fmt.Errorf("unable to parse rating %q", s)
+		}
+	}
+	return percent, votes, nil
+}
+
+// countOrFalse parses s, returning false if it could not be parsed
+func countOrFalse(s string) (int, bool) {
+	i, err := ParseCount(s)
+	return i, err == nil
+}
+
+// CompletedCount returns Completed as an int (see ParseCount for precision)
+func (u *UserStats) CompletedCount() (int, bool) {
+	return countOrFalse(u.Completed)
+}
+
+// BacklogCount returns Backlog as an int (see ParseCount for precision)
+func (u *UserStats) BacklogCount() (int, bool) {
+	return countOrFalse(u.Backlog)
+}
+
+// PlayingCount returns Playing as an int (see ParseCount for precision)
+func (u *UserStats) PlayingCount() (int, bool) {
+	return countOrFalse(u.Playing)
+}
+
+// RetiredCount returns Retired as an int (see ParseCount for precision)
+func (u *UserStats) RetiredCount() (int, bool) {
+	return countOrFalse(u.Retired)
+}
+
+// SpeedRunsCount returns SpeedRuns as an int (see ParseCount for precision)
+func (u *UserStats) SpeedRunsCount() (int, bool) {
+	return countOrFalse(u.SpeedRuns)
+}
+
+// RatingPercent returns the percentage portion of Rating, i.e. 87 for "87% by 590"
+func (u *UserStats) RatingPercent() (float64, bool) {
+	p, _, err := ParseRating(u.Rating)
+	return p, err == nil
+}
+
+// RatingVotes returns the number of users that rated the game, i.e. 590 for
+// "87% by 590" (see ParseCount for precision)
+func (u *UserStats) RatingVotes() (int, bool) {
+	_, v, err := ParseRating(u.Rating)
+	return v, err == nil
+}
+
+// BacklogCount returns Backlog as an int (see ParseCount for precision)
+func (u *UserResult) BacklogCount() (int, bool) {
+	return countOrFalse(u.Backlog)
+}
+
+// CompleteCount returns Complete as an int (see ParseCount for precision)
+func (u *UserResult) CompleteCount() (int, bool) {
+	return countOrFalse(u.Complete)
+}
+
+// PostsCount returns Posts as an int (see ParseCount for precision)
+func (u *UserResult) PostsCount() (int, bool) {
+	return countOrFalse(u.Posts)
+}
@@ -0,0 +1,71 @@
+package gohltb
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseCount(t *testing.T) {
+	tests := map[string]int{
+		"5.3K":  5300,
+		"2.6k":  2600,
+		"919":   919,
+		"1,234": 1234,
+		"1.2M":  1200000,
+		" 56 ":  56,
+	}
+	for in, expected := range tests {
+		i, err := ParseCount(in)
+		if err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
+		if i != expected {
+			fmt.Printf("Got %v, expected %v\n", i, expected)
+			t.Fail()
+		}
+	}
+	for _, in := range []string{"", "K", "abc", "-5", "NaN", "Inf", "1e20", "1.2.3K", "99999999999999999999"} {
+		if _, err := ParseCount(in); err == nil {
+			fmt.Printf("Expected error for %q\n", in)
+			t.Fail()
+		}
+	}
+}
+
+func TestParseRating(t *testing.T) {
+	p, v, err := ParseRating("87% by 1.1K")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if p != 87 || v != 1100 {
+		fmt.Printf("Got %v by %v, expected 87 by 1100", p, v)
+		t.Fail()
+	}
+	p, v, err = ParseRating("85%")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if p != 85 || v != 0 {
+		fmt.Printf("Got %v by %v, expected 85 by 0", p, v)
+		t.Fail()
+	}
+	if _, _, err := ParseRating("NR"); err == nil {
+		fmt.Println("Expected error for NR")
+		t.Fail()
+	}
+}
+
+func TestUserStatsCounts(t *testing.T) {
+	res, err := makeGameCall("testdata/games/userstats.html", &HLTBQuery{Page: 2, Modifier: "user_stats"})
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if c, ok := res.Games[1].UserStats.CompletedCount(); !ok || c != 5300 {
+		fmt.Printf("Got %v, expected 5300", c)
+		t.Fail()
+	}
+	if p, ok := res.Games[0].UserStats.RatingPercent(); !ok || p != 87 {
+		fmt.Printf("Got %v, expected 87", p)
+		t.Fail()
+	}
+}
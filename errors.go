@@ -0,0 +1,91 @@
+package gohltb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrNoMorePages is returned by GetNextPage when there is no next page to retrieve
+var ErrNoMorePages = errors.New("Page not found")
+
+// ErrNilPage is returned when a client's Backend returns a nil page, game or
+// user without an error
+var ErrNilPage = errors.New("Backend returned a nil page")
+
+// ErrLayoutChanged is matched by the LayoutError returned when a page from
+// howlongtobeat.com is missing elements the scraper relies on
+var ErrLayoutChanged = errors.New("Page layout has changed")
+
+// bodySnippetLength is the maximum number of bytes of a response body kept in
+// an HTTPStatusError
+const bodySnippetLength = 512
+
+// HTTPStatusError is returned when howlongtobeat.com responds with a non-200
+// status code.
+type HTTPStatusError struct {
+	StatusCode int    // Status code of the response
+	URL        string // URL that was requested
+	Body       string // The start of the response body, useful for diagnosing the failure
+	retryAfter time.Duration
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("Error retrieving data: %v returned status %v", e.URL, e.StatusCode)
+}
+
+// ParseError is returned when an expected element could not be found in the
+// page returned by howlongtobeat.com, which usually means the layout of the site
+// has changed.
+type ParseError struct {
+	Selector string // Selector or element that could not be parsed
+	Page     int    // Page number of the query being parsed
+	Err      error  // Underlying error, if any
+}
+
+func (e *ParseError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("Error parsing %q on page %v: %v", e.Selector, e.Page, e.Err)
+	}
+	return fmt.Sprintf("Error parsing %q on page %v", e.Selector, e.Page)
+}
+
+// Unwrap returns the underlying error
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// LayoutError is returned when a search results page is missing elements
+// that are expected on every page of results, which means the layout of the
+// site has changed. It matches ErrLayoutChanged with errors.Is.
+type LayoutError struct {
+	Selectors []string // Selectors that did not match anything on the page
+	Page      int      // Page number of the query being parsed
+	HTML      string   // HTML of the page as it was received, only set when the client's DumpHTML is enabled
+}
+
+func (e *LayoutError) Error() string {
+	return fmt.Sprintf("%v: page %v is missing %v", ErrLayoutChanged, e.Page, strings.Join(e.Selectors, ", "))
+}
+
+// Is reports whether target is ErrLayoutChanged
+func (e *LayoutError) Is(target error) bool {
+	return target == ErrLayoutChanged
+}
+
+// RetryError is returned when a request still fails after being retried. It
+// wraps the error from the final attempt.
+type RetryError struct {
+	Attempts int   // Number of attempts made
+	Err      error // Error from the final attempt
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%v (after %v attempts)", e.Err, e.Attempts)
+}
+
+// Unwrap returns the error from the final attempt
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,115 @@
+package gohltb
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// FetchGamePages retrieves pages from through to (inclusive) of a game query,
+// using up to concurrency simultaneous requests. Pages are returned in page
+// order. If any page fails, the remaining requests are cancelled and the first
+// error is returned.
+//
+// The page count of a query is available from the TotalPages of its first page,
+// so a typical use would be to retrieve page 1 and then fetch 2..TotalPages.
+func (h *HLTBClient) FetchGamePages(ctx context.Context, q *HLTBQuery, from, to, concurrency int) ([]*GameResultsPage, error) {
+	if err := checkPageRange(from, to); err != nil {
+		return nil, err
+	}
+	pages := make([]*GameResultsPage, to-from+1)
+	err := fetchConcurrently(ctx, from, to, concurrency, func(ctx context.Context, p int) error {
+		query := *q
+		query.Page = p
+		res, err := gameSearch(ctx, h, &query)
+		if err != nil {
+			return err
+		}
+		pages[p-from] = res
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+// FetchUserPages retrieves pages from through to (inclusive) of a user query,
+// using up to concurrency simultaneous requests. Pages are returned in page
+// order. If any page fails, the remaining requests are cancelled and the first
+// error is returned.
+func (h *HLTBClient) FetchUserPages(ctx context.Context, q *HLTBQuery, from, to, concurrency int) ([]*UserResultsPage, error) {
+	if err := checkPageRange(from, to); err != nil {
+		return nil, err
+	}
+	pages := make([]*UserResultsPage, to-from+1)
+	err := fetchConcurrently(ctx, from, to, concurrency, func(ctx context.Context, p int) error {
+		query := *q
+		query.Page = p
+		res, err := userSearch(ctx, h, &query)
+		if err != nil {
+			return err
+		}
+		pages[p-from] = res
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+// checkPageRange validates the page range passed to the Fetch methods
+func checkPageRange(from, to int) error {
+	if from < 1 || to < from {
+		return fmt.Errorf("invalid page range %v to %v", from, to)
+	}
+	return nil
+}
+
+// fetchConcurrently runs fetch for each page from through to using a pool of
+// concurrency workers. The first error cancels the context handed to the
+// remaining fetches and is returned once all workers have stopped.
+func fetchConcurrently(ctx context.Context, from, to, concurrency int, fetch func(context.Context, int) error) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range jobs {
+				if err := fetch(ctx, p); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for p := from; p <= to; p++ {
+		select {
+		case jobs <- p:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,56 @@
+package gohltb
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchGamePages(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/games/multipage2.html")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, string(data))
+	}))
+	defer ts.Close()
+	client := NewCustomClient(&HTTPClient{baseURL: ts.URL})
+
+	pages, err := client.FetchGamePages(context.Background(), &HLTBQuery{}, 2, 9, 3)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(pages) != 8 {
+		t.Fatalf("Got %v, expected 8", len(pages))
+	}
+	for i, p := range pages {
+		if p.CurrentPage != i+2 {
+			fmt.Printf("Got %v, expected %v\n", p.CurrentPage, i+2)
+			t.Fail()
+		}
+	}
+}
+
+func TestFetchUserPagesError(t *testing.T) {
+	ts := makePagedServer(t, "testdata/users/multipage.html", "testdata/users/multipage2.html")
+	defer ts.Close()
+	client := NewCustomClient(&HTTPClient{baseURL: ts.URL})
+
+	pages, err := client.FetchUserPages(context.Background(), &HLTBQuery{}, 1, 4, 2)
+	if err == nil {
+		t.Fatal("Expected error for missing pages")
+	}
+	if pages != nil {
+		fmt.Println("Expected nil pages")
+		t.Fail()
+	}
+
+	if _, err := client.FetchUserPages(context.Background(), &HLTBQuery{}, 3, 1, 2); err == nil {
+		fmt.Println("Expected error for invalid range")
+		t.Fail()
+	}
+}
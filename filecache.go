@@ -0,0 +1,157 @@
+package gohltb
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// fileCacheExt is the extension given to every file written by FileCache
+const fileCacheExt = ".hltb"
+
+// FileCache is a Cache that stores responses as files in a directory, allowing
+// responses to be reused between runs of a program. Entries expire once they
+// are older than the cache's TTL, and when the files in the directory grow past
+// the size limit the oldest entries are removed.
+//
+// Files are written atomically, so a FileCache directory can safely be shared
+// by multiple processes.
+//
+// example:
+//
+//	cache, err := gohltb.NewFileCache("/tmp/gohltb", time.Hour, 50<<20)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	client := gohltb.NewDefaultClient()
+//	client.Cache = cache
+type FileCache struct {
+	hits     uint64 // kept first for 64-bit alignment of atomic operations
+	misses   uint64
+	dir      string
+	ttl      time.Duration
+	maxBytes int64
+	mu       sync.Mutex // serializes size enforcement within this process
+}
+
+// NewFileCache creates a FileCache in dir, creating the directory if needed.
+// Entries expire after ttl, and the total size of the cache is kept under
+// maxBytes. A ttl of 0 means entries never expire, and a maxBytes of 0 means
+// there is no size limit.
+func NewFileCache(dir string, ttl time.Duration, maxBytes int64) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir, ttl: ttl, maxBytes: maxBytes}, nil
+}
+
+// Get returns the value stored for key, if it exists and has not expired
+func (c *FileCache) Get(key string) ([]byte, bool) {
+	path := c.path(key)
+	info, err := os.Stat(path)
+	if err != nil {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, false
+	}
+	if c.ttl > 0 && time.Since(info.ModTime()) > c.ttl {
+		os.Remove(path)
+		atomic.AddUint64(&c.misses, 1)
+		return nil, false
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, false
+	}
+	atomic.AddUint64(&c.hits, 1)
+	return data, true
+}
+
+// Set stores value for key. The value is written to a temporary file which is
+// then renamed into place, so readers never see a partially written entry.
+func (c *FileCache) Set(key string, value []byte) {
+	tmp, err := ioutil.TempFile(c.dir, ".tmp-")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(value)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if c.maxBytes > 0 {
+		c.enforceSize()
+	}
+}
+
+// Stats returns the hit and miss counters for this FileCache, along with the
+// number of entries currently in the directory.
+func (c *FileCache) Stats() CacheStats {
+	return CacheStats{
+		Hits:    atomic.LoadUint64(&c.hits),
+		Misses:  atomic.LoadUint64(&c.misses),
+		Entries: len(c.files()),
+	}
+}
+
+// path returns the file used to store key. Keys are hashed, as they contain
+// characters that aren't valid in file names.
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+fileCacheExt)
+}
+
+// files lists the entries in the cache directory
+func (c *FileCache) files() []os.FileInfo {
+	infos, err := ioutil.ReadDir(c.dir)
+	if err != nil {
+		return nil
+	}
+	var files []os.FileInfo
+	for _, info := range infos {
+		if !info.IsDir() && strings.HasSuffix(info.Name(), fileCacheExt) {
+			files = append(files, info)
+		}
+	}
+	return files
+}
+
+// enforceSize removes the oldest entries until the cache is under its size limit
+func (c *FileCache) enforceSize() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	files := c.files()
+	var total int64
+	for _, f := range files {
+		total += f.Size()
+	}
+	if total <= c.maxBytes {
+		return
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+	for _, f := range files {
+		if total <= c.maxBytes {
+			break
+		}
+		if err := os.Remove(filepath.Join(c.dir, f.Name())); err == nil || os.IsNotExist(err) {
+			total -= f.Size()
+		}
+	}
+}
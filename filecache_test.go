@@ -0,0 +1,132 @@
+package gohltb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestFileCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gohltb")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache, err := NewFileCache(dir, time.Minute, 0)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if _, ok := cache.Get("missing"); ok {
+		fmt.Println("Expected miss for missing key")
+		t.Fail()
+	}
+	cache.Set("https://howlongtobeat.com/search_results?page=1&queryString=mario", []byte("<html>"))
+
+	// a new FileCache on the same directory should see the stored entry
+	cache2, err := NewFileCache(dir, time.Minute, 0)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	v, ok := cache2.Get("https://howlongtobeat.com/search_results?page=1&queryString=mario")
+	if !ok || string(v) != "<html>" {
+		fmt.Printf("Got %v, expected <html>", string(v))
+		t.Fail()
+	}
+	if stats := cache2.Stats(); stats.Hits != 1 || stats.Entries != 1 {
+		fmt.Printf("Got %+v, expected 1 hit and 1 entry", stats)
+		t.Fail()
+	}
+}
+
+func TestFileCacheSizeLimit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gohltb")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache, err := NewFileCache(dir, 0, 25)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	cache.Set("a", []byte("0123456789"))
+	// make sure "a" is the oldest entry
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(cache.path("a"), old, old)
+	cache.Set("b", []byte("0123456789"))
+	cache.Set("c", []byte("0123456789"))
+
+	if _, ok := cache.Get("a"); ok {
+		fmt.Println("Expected oldest entry to be removed")
+		t.Fail()
+	}
+	if _, ok := cache.Get("c"); !ok {
+		fmt.Println("Expected newest entry to be kept")
+		t.Fail()
+	}
+}
+
+func TestFileCacheExpiry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gohltb")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache, err := NewFileCache(dir, time.Minute, 0)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	cache.Set("a", []byte("1"))
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(cache.path("a"), old, old)
+	if _, ok := cache.Get("a"); ok {
+		fmt.Println("Expected entry to have expired")
+		t.Fail()
+	}
+}
+
+func TestFileCachedSearch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gohltb")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	defer os.RemoveAll(dir)
+
+	data, err := ioutil.ReadFile("testdata/users/mixed_response.html")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintln(w, string(data))
+	}))
+	defer ts.Close()
+
+	for i := 0; i < 2; i++ {
+		cache, err := NewFileCache(dir, time.Minute, 0)
+		if err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
+		client := NewCustomClient(&HTTPClient{baseURL: ts.URL})
+		client.Cache = cache
+		res, err := client.SearchUsers("tiamat")
+		if err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
+		if len(res.Users) != 2 {
+			fmt.Printf("Got %v, expected 2", len(res.Users))
+			t.Fail()
+		}
+	}
+	if requests != 1 {
+		fmt.Printf("Got %v, expected 1", requests)
+		t.Fail()
+	}
+}
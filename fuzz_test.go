@@ -0,0 +1,107 @@
+//go:build go1.18
+// +build go1.18
+
+package gohltb
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// addSeeds adds every file matching pattern to the fuzz corpus
+func addSeeds(f *testing.F, pattern string) {
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		f.Fatal("Unexpected error: ", err)
+	}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			f.Fatal("Unexpected error: ", err)
+		}
+		f.Add(data)
+	}
+}
+
+// fuzzDocument parses data as a page, skipping input goquery can't read
+func fuzzDocument(t *testing.T, data []byte) *goquery.Document {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(data))
+	if err != nil {
+		t.Skip()
+	}
+	return doc
+}
+
+func FuzzParseGameResponse(f *testing.F) {
+	addSeeds(f, "testdata/games/*.html")
+	f.Fuzz(func(t *testing.T, data []byte) {
+		doc := fuzzDocument(t, data)
+		for _, page := range []int{1, 2} {
+			if found, err := hasResults(doc, page, defaultSelectors); err != nil || !found {
+				continue
+			}
+			q := &HLTBQuery{Page: page, Modifier: ShowUserStats}
+			if res, err := parseGameResponse(doc, q, defaultSelectors); err == nil && res == nil {
+				t.Fatal("Got nil page without an error")
+			}
+		}
+	})
+}
+
+func FuzzParseUserResponse(f *testing.F) {
+	addSeeds(f, "testdata/users/*.html")
+	f.Fuzz(func(t *testing.T, data []byte) {
+		doc := fuzzDocument(t, data)
+		for _, page := range []int{1, 2} {
+			if found, err := hasResults(doc, page, defaultSelectors); err != nil || !found {
+				continue
+			}
+			if res, err := parseUserResponse(doc, &HLTBQuery{Page: page}, defaultSelectors); err == nil && res == nil {
+				t.Fatal("Got nil page without an error")
+			}
+		}
+	})
+}
+
+func FuzzParseGameDetail(f *testing.F) {
+	addSeeds(f, "testdata/games/*.html")
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if res, err := parseGameDetail(fuzzDocument(t, data), "1", defaultSelectors); err == nil && res == nil {
+			t.Fatal("Got nil game without an error")
+		}
+	})
+}
+
+func FuzzParseUserProfile(f *testing.F) {
+	addSeeds(f, "testdata/users/*.html")
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if res, err := parseUserProfile(fuzzDocument(t, data), "user", defaultSelectors); err == nil && res == nil {
+			t.Fatal("Got nil profile without an error")
+		}
+	})
+}
+
+func FuzzParseUserGames(f *testing.F) {
+	addSeeds(f, "testdata/users/*.html")
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if res, err := parseUserGames(fuzzDocument(t, data), 1, defaultSelectors); err == nil && res == nil {
+			t.Fatal("Got nil page without an error")
+		}
+	})
+}
+
+func FuzzParseCompletionTime(f *testing.F) {
+	for _, s := range []string{"13½ Hours", "45 Mins", "--", "1¼ Hours", ""} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		ParseCompletionTime(s)
+		ParseShortDuration(s)
+		ParseCount(s)
+		ParseRating(s)
+	})
+}
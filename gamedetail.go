@@ -0,0 +1,320 @@
+package gohltb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// GameDetail is the data object for a game's page on howlongtobeat.com. It
+// contains everything from a GameResult, as well as the additional details that
+// are only displayed on the game's own page.
+//
+// Completion times break down the same as for a GameResult, with the addition
+// of AllStyles, which is the time across all play styles.
+type GameDetail struct {
+	ID            string                     `json:"id"`                       // ID in howlongtobeat.com's database
+	Title         string                     `json:"title"`                    // Title of game
+	URL           string                     `json:"url"`                      // Link to game page
+	BoxArtURL     string                     `json:"box-art-url"`              // Link to boxart
+	Description   string                     `json:"description,omitempty"`    // Summary of the game
+	Developer     string                     `json:"developer,omitempty"`      // Developer(s) of the game
+	Publisher     string                     `json:"publisher,omitempty"`      // Publisher(s) of the game
+	Genres        []string                   `json:"genres,omitempty"`         // Genres the game belongs to
+	Platforms     []Platform                 `json:"platforms,omitempty"`      // Platforms the game is available on
+	ReleaseDates  map[string]string          `json:"release-dates,omitempty"`  // Release date by region (NA, EU, JP)
+	Main          string                     `json:"main"`                     // Completion time for Main category
+	MainExtra     string                     `json:"main-extra"`               // Completion time for Main + Extra category
+	Completionist string                     `json:"completionist"`            // Completion time for Completionist category
+	AllStyles     string                     `json:"all-styles"`               // Completion time across all play styles
+	Other         map[string]string          `json:"other,omitempty"`          // Times that don't fall under the main time categories
+	TimeStats     *GameTimeStats             `json:"time-stats,omitempty"`     // Breakdown of the submitted times for each category
+	PlatformTimes map[Platform]PlatformTimes `json:"platform-times,omitempty"` // Breakdown of the submitted times for each platform
+}
+
+// PlatformTimes are the times submitted by users for a game on a single
+// platform. Durations are 0 when the site has no value for them.
+type PlatformTimes struct {
+	Polled        int           `json:"polled"`        // Number of users that submitted a time (see ParseCount for precision)
+	Main          time.Duration `json:"main"`          // Average Main Story time
+	MainExtra     time.Duration `json:"main-extra"`    // Average Main + Extras time
+	Completionist time.Duration `json:"completionist"` // Average Completionist time
+	Fastest       time.Duration `json:"fastest"`       // Fastest submitted time
+	Slowest       time.Duration `json:"slowest"`       // Slowest submitted time
+}
+
+// TimeStats is the breakdown of the times submitted by users for a single
+// completion category. Durations are 0 when the site has no value for them.
+type TimeStats struct {
+	Polled  int           `json:"polled"`  // Number of users that submitted a time (see ParseCount for precision)
+	Average time.Duration `json:"average"` // Average of the submitted times
+	Median  time.Duration `json:"median"`  // Median of the submitted times
+	Rushed  time.Duration `json:"rushed"`  // Time for players that rushed through
+	Leisure time.Duration `json:"leisure"` // Time for players that took their time
+}
+
+// GameTimeStats are the TimeStats for each of the completion categories on a
+// game's page. Categories that have no submissions are nil.
+type GameTimeStats struct {
+	Main          *TimeStats           `json:"main,omitempty"`          // Main Story
+	MainExtra     *TimeStats           `json:"main-extra,omitempty"`    // Main + Extras
+	Completionist *TimeStats           `json:"completionist,omitempty"` // Completionist
+	AllStyles     *TimeStats           `json:"all-styles,omitempty"`    // All PlayStyles
+	Other         map[string]TimeStats `json:"other,omitempty"`         // Other categories, such as multiplayer times
+}
+
+// JSON will convert a game detail object into a json string
+func (g *GameDetail) JSON() (string, error) {
+	var r string
+	s, err := json.MarshalIndent(g, "", "  ")
+	if err == nil {
+		r = string(s)
+	}
+	return r, err
+}
+
+// GetGame retrieves the details for the game with the provided ID, which is the
+// ID found on a GameResult.
+func (h *HLTBClient) GetGame(ctx context.Context, id string) (*GameDetail, error) {
+	if id == "" {
+		return nil, errors.New("A game ID is required")
+	}
+	game, err := h.backend().GetGame(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if game == nil {
+		return nil, ErrNilPage
+	}
+	return game, nil
+}
+
+// GetGameTimeStats retrieves the breakdown of submitted completion times for
+// the game with the provided ID. This is the same as the TimeStats of GetGame.
+func (h *HLTBClient) GetGameTimeStats(ctx context.Context, id string) (*GameTimeStats, error) {
+	game, err := h.GetGame(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if game.TimeStats == nil {
+		return &GameTimeStats{}, nil
+	}
+	return game.TimeStats, nil
+}
+
+// parseGameDetail parses a game page into a GameDetail object
+func parseGameDetail(doc *goquery.Document, id string, sel *Selectors) (*GameDetail, error) {
+	page := sel.GamePage
+	labels := page.Labels
+	header := doc.Find(page.Title)
+	if header.Length() == 0 {
+		return nil, &ParseError{Selector: page.Title}
+	}
+	boxArt, _ := doc.Find(page.BoxArt).Attr("src")
+
+	game := &GameDetail{
+		ID:        id,
+		URL:       urlPrefix + sel.GameLink + id,
+		BoxArtURL: boxArt,
+		Title:     sanitizeTitle(header.First().Text()),
+	}
+
+	// Handle the summary of completion times at the top of the page
+	doc.Find(page.Time).Each(func(timeCount int, timeDetail *goquery.Selection) {
+		value := strings.TrimSpace(timeDetail.Find(page.TimeValue).First().Text())
+		switch timeType := strings.TrimSpace(timeDetail.Find(page.TimeLabel).Text()); {
+		case contains(labels.Main, timeType):
+			game.Main = value
+		case contains(labels.MainExtra, timeType):
+			game.MainExtra = value
+		case contains(labels.Completionist, timeType):
+			game.Completionist = value
+		case contains(labels.AllStyles, timeType):
+			game.AllStyles = value
+		default:
+			if game.Other == nil {
+				game.Other = make(map[string]string)
+			}
+			game.Other[timeType] = value
+		}
+	})
+
+	// Handle the game's profile details, each of which is a label followed by its value
+	doc.Find(page.Info).Each(func(infoCount int, info *goquery.Selection) {
+		if info.Is(page.Description) {
+			summary := info.Clone()
+			summary.Find(page.ReadMore).Remove()
+			game.Description = sanitizeTitle(summary.Text())
+			return
+		}
+		label := info.Find(page.InfoLabel).First()
+		category := strings.TrimSuffix(strings.TrimSpace(label.Text()), ":")
+		value := sanitizeTitle(strings.Replace(info.Text(), label.Text(), "", 1))
+		switch {
+		case contains(labels.Platforms, category):
+			for _, name := range splitList(value) {
+				p, _ := ParsePlatform(name)
+				game.Platforms = append(game.Platforms, p)
+			}
+		case contains(labels.Genres, category):
+			game.Genres = splitList(value)
+		case contains(labels.Developer, category):
+			game.Developer = value
+		case contains(labels.Publisher, category):
+			game.Publisher = value
+		case contains(labels.ReleaseDates, category):
+			if game.ReleaseDates == nil {
+				game.ReleaseDates = make(map[string]string)
+			}
+			game.ReleaseDates[category] = value
+		}
+	})
+
+	// Handle the tables of submitted times. Each table has a header row naming
+	// its columns, so the columns are matched by name rather than position.
+	doc.Find(page.Table).Each(func(tableCount int, table *goquery.Selection) {
+		headers, rows := parseTable(table, &page)
+		if len(headers) > 0 && contains(labels.Platform, headers[0]) {
+			for _, row := range rows {
+				if game.PlatformTimes == nil {
+					game.PlatformTimes = make(map[Platform]PlatformTimes)
+				}
+				// platforms the library doesn't know about are kept under the site's name
+				p, _ := ParsePlatform(row[0])
+				game.PlatformTimes[p] = parsePlatformTimes(headers, row, &labels)
+			}
+			return
+		}
+		if !containsAny(headers, labels.Median) {
+			return
+		}
+		for _, row := range rows {
+			stats := parseTimeStats(headers, row, &labels)
+			if game.TimeStats == nil {
+				game.TimeStats = &GameTimeStats{}
+			}
+			switch {
+			case contains(labels.Main, row[0]):
+				game.TimeStats.Main = stats
+			case contains(labels.MainExtra, row[0]):
+				game.TimeStats.MainExtra = stats
+			case contains(labels.Completionist, row[0]):
+				game.TimeStats.Completionist = stats
+			case contains(labels.AllStyles, row[0]):
+				game.TimeStats.AllStyles = stats
+			default:
+				if game.TimeStats.Other == nil {
+					game.TimeStats.Other = make(map[string]TimeStats)
+				}
+				game.TimeStats.Other[row[0]] = *stats
+			}
+		}
+	})
+
+	return game, nil
+}
+
+// parseTimeStats converts a row of a submitted times table into TimeStats
+func parseTimeStats(headers []string, row []string, labels *GamePageLabels) *TimeStats {
+	stats := &TimeStats{}
+	for i, header := range headers {
+		if i >= len(row) {
+			break
+		}
+		d, _ := ParseShortDuration(row[i])
+		switch {
+		case contains(labels.Polled, header):
+			stats.Polled, _ = ParseCount(row[i])
+		case contains(labels.Average, header):
+			stats.Average = d
+		case contains(labels.Median, header):
+			stats.Median = d
+		case contains(labels.Rushed, header):
+			stats.Rushed = d
+		case contains(labels.Leisure, header):
+			stats.Leisure = d
+		}
+	}
+	return stats
+}
+
+// parsePlatformTimes converts a row of the platform table into PlatformTimes
+func parsePlatformTimes(headers []string, row []string, labels *GamePageLabels) PlatformTimes {
+	times := PlatformTimes{}
+	for i, header := range headers {
+		if i >= len(row) {
+			break
+		}
+		d, _ := ParseShortDuration(row[i])
+		switch {
+		case contains(labels.Polled, header):
+			times.Polled, _ = ParseCount(row[i])
+		case contains(labels.PlatformMain, header):
+			times.Main = d
+		case contains(labels.PlatformMainExtra, header):
+			times.MainExtra = d
+		case contains(labels.PlatformCompletionist, header):
+			times.Completionist = d
+		case contains(labels.Fastest, header):
+			times.Fastest = d
+		case contains(labels.Slowest, header):
+			times.Slowest = d
+		}
+	}
+	return times
+}
+
+// parseTable reads the header and rows of a table on a detail page. Rows with
+// no cells are skipped, so every returned row has at least one value.
+func parseTable(table *goquery.Selection, page *GamePage) ([]string, [][]string) {
+	var headers []string
+	table.Find(page.TableHeader).Each(func(i int, cell *goquery.Selection) {
+		headers = append(headers, strings.TrimSpace(cell.Text()))
+	})
+	var rows [][]string
+	table.Find(page.TableRow).Each(func(i int, tr *goquery.Selection) {
+		var row []string
+		tr.Find(page.TableCell).Each(func(j int, cell *goquery.Selection) {
+			row = append(row, strings.TrimSpace(cell.Text()))
+		})
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+	})
+	return headers, rows
+}
+
+// contains checks if a slice of strings contains e
+func contains(s []string, e string) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAny checks if a slice of strings contains any of es
+func containsAny(s []string, es []string) bool {
+	for _, e := range es {
+		if contains(s, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitList splits a comma separated list of values, as used on detail pages
+func splitList(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
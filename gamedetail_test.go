@@ -0,0 +1,137 @@
+package gohltb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func makeGameDetailCall(file string, id string) (*GameDetail, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/game" || r.URL.Query().Get("id") != id {
+			w.WriteHeader(404)
+			return
+		}
+		fmt.Fprintln(w, string(data))
+	}))
+	defer ts.Close()
+
+	client := NewCustomClient(&HTTPClient{baseURL: ts.URL})
+	return client.GetGame(context.Background(), id)
+}
+
+func TestGameDetailParse(t *testing.T) {
+	game, err := makeGameDetailCall("testdata/games/detail.html", "57506")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if game.Title != "Doom Eternal" {
+		fmt.Printf("Got %v, expected Doom Eternal", game.Title)
+		t.Fail()
+	}
+	if game.Developer != "id Software" || game.Publisher != "Bethesda Softworks" {
+		fmt.Printf("Got %v/%v, expected id Software/Bethesda Softworks", game.Developer, game.Publisher)
+		t.Fail()
+	}
+	if len(game.Genres) != 3 || game.Genres[2] != "Shooter" {
+		fmt.Printf("Got %v, expected 3 genres", game.Genres)
+		t.Fail()
+	}
+	if len(game.Platforms) != 5 || game.Platforms[1] != NintendoSwitch {
+		fmt.Printf("Got %v, expected 5 platforms", game.Platforms)
+		t.Fail()
+	}
+	if game.ReleaseDates["EU"] != "March 20th, 2020" {
+		fmt.Printf("Got %v, expected March 20th, 2020", game.ReleaseDates["EU"])
+		t.Fail()
+	}
+	if game.Main != "13½ Hours" || game.MainExtra != "18 Hours" || game.AllStyles != "17½ Hours" {
+		fmt.Printf("Got %v/%v/%v, unexpected times", game.Main, game.MainExtra, game.AllStyles)
+		t.Fail()
+	}
+	if game.Other["Vs."] != "--" {
+		fmt.Printf("Got %v, expected --", game.Other["Vs."])
+		t.Fail()
+	}
+	if game.Description == "" || game.URL != urlPrefix+"game?id=57506" {
+		fmt.Printf("Got %v, %v, unexpected description/url", game.Description, game.URL)
+		t.Fail()
+	}
+	game.JSON()
+}
+
+func TestGameDetailNotFound(t *testing.T) {
+	_, err := makeGameDetailCall("testdata/games/notfound.html", "1")
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		fmt.Printf("Got %v, expected ParseError", err)
+		t.Fail()
+	}
+}
+
+func TestGameTimeStatsParse(t *testing.T) {
+	game, err := makeGameDetailCall("testdata/games/detail.html", "57506")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	stats := game.TimeStats
+	if stats == nil || stats.Main == nil || stats.AllStyles == nil {
+		t.Fatal("Expected time stats for Main and All PlayStyles")
+	}
+	if stats.Main.Polled != 1100 {
+		fmt.Printf("Got %v, expected 1100", stats.Main.Polled)
+		t.Fail()
+	}
+	if stats.Main.Average != 13*time.Hour+24*time.Minute || stats.Main.Median != 13*time.Hour {
+		fmt.Printf("Got %v/%v, expected 13h24m/13h", stats.Main.Average, stats.Main.Median)
+		t.Fail()
+	}
+	if stats.Completionist.Leisure != 37*time.Hour+10*time.Minute {
+		fmt.Printf("Got %v, expected 37h10m", stats.Completionist.Leisure)
+		t.Fail()
+	}
+	if stats.MainExtra.Rushed != 14*time.Hour+2*time.Minute {
+		fmt.Printf("Got %v, expected 14h2m", stats.MainExtra.Rushed)
+		t.Fail()
+	}
+}
+
+func TestPlatformTimesParse(t *testing.T) {
+	game, err := makeGameDetailCall("testdata/games/detail.html", "57506")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(game.PlatformTimes) != 3 {
+		t.Fatalf("Got %v, expected 3", len(game.PlatformTimes))
+	}
+	ps4, ok := game.PlatformTimes[PlayStation4]
+	if !ok || ps4.Polled != 520 || ps4.Slowest != 45*time.Hour+30*time.Minute {
+		fmt.Printf("Got %+v, unexpected PlayStation 4 times", ps4)
+		t.Fail()
+	}
+	switchTimes, ok := game.PlatformTimes[NintendoSwitch]
+	if !ok || switchTimes.MainExtra != 0 || switchTimes.Completionist != 26*time.Hour {
+		fmt.Printf("Got %+v, unexpected Nintendo Switch times", switchTimes)
+		t.Fail()
+	}
+}
+
+func TestParsePlatform(t *testing.T) {
+	if p, ok := ParsePlatform("playstation 4"); !ok || p != PlayStation4 {
+		fmt.Printf("Got %v, expected PlayStation 4", p)
+		t.Fail()
+	}
+	if p, ok := ParsePlatform("Holodeck"); ok || p != "Holodeck" {
+		fmt.Printf("Got %v, expected unknown Holodeck", p)
+		t.Fail()
+	}
+}
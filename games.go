@@ -1,6 +1,7 @@
 package gohltb
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"strings"
@@ -10,8 +11,9 @@ import (
 
 // GameResult are the data object for Games. Games contain the thing that
 // you're probably most interested in, which are the completion times.
-// All of the times that are collected are presented as strings, you will
-// need to handle any kind of conversions if you need them as numbers.
+// All of the times that are collected are presented as strings. Parsed
+// versions are available through MainTime, MainExtraTime, CompletionistTime
+// and OtherTimes (see times.go).
 //
 // Completion times break down as:
 //     * Main - time to complete the main game
@@ -29,6 +31,7 @@ type GameResult struct {
 	Completionist string            `json:"completionist"`        // Completion time for Completionist category
 	Other         map[string]string `json:"other,omitempty"`      // Times that don't fall under the main 3 time categories
 	UserStats     *UserStats        `json:"user-stats,omitempty"` // Optional additional user details (only present when requested)
+	Extra         map[string]string `json:"extra,omitempty"`      // Details with labels the library doesn't recognise, keyed by label
 }
 
 // UserStats are additional stats based on user activity on howlongtobeat.com
@@ -64,12 +67,26 @@ func (g *GameResultsPage) HasNext() bool {
 // GetNextPage will return the next page, if it exists. Uses the client
 // from the initial request to make additional queries.
 func (g *GameResultsPage) GetNextPage() (*GameResultsPage, error) {
+	return g.GetNextPageContext(context.Background())
+}
+
+// GetNextPageContext is the same as GetNextPage, but the request is bound
+// to the provided context.
+func (g *GameResultsPage) GetNextPageContext(ctx context.Context) (*GameResultsPage, error) {
 	if !g.HasNext() {
-		return &GameResultsPage{}, errors.New("Page not found")
+		return &GameResultsPage{}, ErrNoMorePages
 	}
 	query := g.requestQuery
 	query.Page = g.NextPage
-	return g.hltbClient.SearchGamesByQuery(query)
+	res, err := g.hltbClient.SearchGamesByQueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	// Only the first page has the number of matches, so carry it forward
+	if res.TotalMatches == 0 {
+		res.TotalMatches = g.TotalMatches
+	}
+	return res, nil
 }
 
 // JSON will convert a game object into a json string
@@ -98,7 +115,13 @@ func (g *GameResultsPage) setTotalMatches(m int) {
 //
 // Note: A query with an empty query string will query ALL games
 func (h *HLTBClient) SearchGames(query string) (*GameResultsPage, error) {
-	return gameSearch(h, &HLTBQuery{Query: query})
+	return gameSearch(context.Background(), h, &HLTBQuery{Query: query})
+}
+
+// SearchGamesContext is the same as SearchGames, but the request is bound
+// to the provided context. Cancelling the context will abort the request.
+func (h *HLTBClient) SearchGamesContext(ctx context.Context, query string) (*GameResultsPage, error) {
+	return gameSearch(ctx, h, &HLTBQuery{Query: query})
 }
 
 // SearchGamesByQuery queries using a set of user defined parameters. Used
@@ -118,24 +141,34 @@ func (h *HLTBClient) SearchGames(query string) (*GameResultsPage, error) {
 //
 // Note: A query with an empty "Query" string will query ALL games.
 func (h *HLTBClient) SearchGamesByQuery(q *HLTBQuery) (*GameResultsPage, error) {
-	return gameSearch(h, q)
+	return gameSearch(context.Background(), h, q)
+}
+
+// SearchGamesByQueryContext is the same as SearchGamesByQuery, but the request
+// is bound to the provided context. Cancelling the context will abort the request.
+func (h *HLTBClient) SearchGamesByQueryContext(ctx context.Context, q *HLTBQuery) (*GameResultsPage, error) {
+	return gameSearch(ctx, h, q)
 }
 
 // gameSearch is the central method for running user queries
-func gameSearch(h *HLTBClient, q *HLTBQuery) (*GameResultsPage, error) {
+func gameSearch(ctx context.Context, h *HLTBClient, q *HLTBQuery) (*GameResultsPage, error) {
 	handleGameDefaults(q)
-	doc, err := searchQuery(h, q)
+	res, err := h.backend().SearchGames(ctx, q)
 	if err != nil {
 		return nil, err
 	}
-	if !hasResults(doc) {
-		return &GameResultsPage{}, nil
-	}
-	res, err := parseGameResponse(doc, q)
-	if err != nil {
-		return nil, err
+	if res == nil {
+		return nil, ErrNilPage
 	}
+	res.requestQuery = q
 	res.hltbClient = h
+	if h.OnUnknownLabel != nil {
+		for _, game := range res.Games {
+			for label, value := range game.Extra {
+				h.OnUnknownLabel(GameQuery, label, value)
+			}
+		}
+	}
 	return res, nil
 }
 
@@ -157,19 +190,26 @@ func handleGameDefaults(q *HLTBQuery) {
 }
 
 // parseGameResponse parses the http response object into a GameResultsPage object
-func parseGameResponse(doc *goquery.Document, q *HLTBQuery) (*GameResultsPage, error) {
+func parseGameResponse(doc *goquery.Document, q *HLTBQuery, sel *Selectors) (*GameResultsPage, error) {
 	games := &GameResultsPage{}
 	var gameslice []*GameResult
 
 	// Handle the page numbers
-	parsePages(doc, games, q.Page)
+	if err := parsePages(doc, games, q.Page, sel); err != nil {
+		return nil, err
+	}
 
 	// Handle each game
-	doc.Find("ul > li.back_darkish").Each(func(gameCount int, gameDetails *goquery.Selection) {
-		boxArt, _ := gameDetails.Find("div > a > img").Attr("src")
-		title := gameDetails.Find("h3 > a")
+	var parseErr error
+	doc.Find(sel.Result).EachWithBreak(func(gameCount int, gameDetails *goquery.Selection) bool {
+		boxArt, _ := gameDetails.Find(sel.ResultImage).Attr("src")
+		title := gameDetails.Find(sel.ResultLink)
 		url, _ := title.Attr("href")
-		id := strings.SplitAfter(url, "game?id=")[1]
+		id, ok := parseID(url, sel.GameLink)
+		if !ok {
+			parseErr = &ParseError{Selector: sel.ResultLink, Page: q.Page, Err: errors.New("game ID not found")}
+			return false
+		}
 
 		game := &GameResult{
 			ID:        id,
@@ -180,36 +220,41 @@ func parseGameResponse(doc *goquery.Document, q *HLTBQuery) (*GameResultsPage, e
 		userStats := &UserStats{}
 
 		// Handle the case of multiplayer games which have non-standard response times
-		if gameDetails.Find(".search_list_details_block").First().Children().First().Is(".search_list_tidbit_short") {
+		if gameDetails.Find(sel.DetailsBlock).First().Children().First().Is(sel.ShortLabel) {
 			otherMap := make(map[string]string)
-			gameDetails.Find(".search_list_tidbit_short").Each(func(timeCount int, timeDetail *goquery.Selection) {
+			gameDetails.Find(sel.ShortLabel).Each(func(timeCount int, timeDetail *goquery.Selection) {
 				otherMap[timeDetail.Text()] = strings.TrimSpace(timeDetail.Next().Text())
 			})
 			game.Other = otherMap
 		} else {
 			// Handle the various items that a game could have. Any of these options may be present,
 			// some of which are only present when ShowUserStats is enabled
-			gameDetails.Find(".search_list_tidbit.text_white").Each(func(timeCount int, timeDetail *goquery.Selection) {
+			gameDetails.Find(sel.Label).Each(func(timeCount int, timeDetail *goquery.Selection) {
 				nextValue := strings.TrimSpace(timeDetail.Next().Text())
 				switch timeType := timeDetail.Text(); {
-				case timeType == "Main Story":
+				case timeType == sel.GameLabels.Main:
 					game.Main = nextValue
-				case timeType == "Main + Extra":
+				case timeType == sel.GameLabels.MainExtra:
 					game.MainExtra = nextValue
-				case timeType == "Completionist":
+				case timeType == sel.GameLabels.Completionist:
 					game.Completionist = nextValue
-				case timeType == "Polled":
+				case timeType == sel.GameLabels.Polled:
 					userStats.Completed = nextValue
-				case timeType == "Rated":
+				case timeType == sel.GameLabels.Rated:
 					userStats.Rating = nextValue
-				case timeType == "Backlog":
+				case timeType == sel.GameLabels.Backlog:
 					userStats.Backlog = nextValue
-				case timeType == "Playing":
+				case timeType == sel.GameLabels.Playing:
 					userStats.Playing = nextValue
-				case timeType == "Speedruns":
+				case timeType == sel.GameLabels.SpeedRuns:
 					userStats.SpeedRuns = nextValue
-				case timeType == "Retired":
+				case timeType == sel.GameLabels.Retired:
 					userStats.Retired = nextValue
+				default:
+					if game.Extra == nil {
+						game.Extra = make(map[string]string)
+					}
+					game.Extra[timeType] = nextValue
 				}
 			})
 		}
@@ -217,7 +262,11 @@ func parseGameResponse(doc *goquery.Document, q *HLTBQuery) (*GameResultsPage, e
 			game.UserStats = userStats
 		}
 		gameslice = append(gameslice, game)
+		return true
 	})
+	if parseErr != nil {
+		return nil, parseErr
+	}
 	games.Games = gameslice
 	games.CurrentPage = q.Page
 	games.requestQuery = q
@@ -1,10 +1,13 @@
 package gohltb
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -135,8 +138,8 @@ func TestMultipleGamePages(t *testing.T) {
 		fmt.Printf("Got %v, expected 3", res.NextPage)
 		t.Fail()
 	}
-	if res.TotalMatches != 0 {
-		fmt.Printf("Got %v, expected 0", res.TotalMatches)
+	if res.TotalMatches != 42848 {
+		fmt.Printf("Got %v, expected 42848", res.TotalMatches)
 		t.Fail()
 	}
 	if res.TotalPages != 2143 {
@@ -171,12 +174,190 @@ func TestNon200GameResponse(t *testing.T) {
 	client := NewCustomClient(&HTTPClient{baseURL: mockURL})
 
 	resp, err := client.SearchGamesByQuery(&HLTBQuery{Query: "bugsnax"})
-	if err.Error() != "Error retrieving data" {
-		fmt.Printf("Got %v, expected Error retrieving data", err.Error())
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != 400 {
+		fmt.Printf("Got %v, expected HTTPStatusError with status 400", err)
+		t.Fail()
+	}
+	if resp != nil {
+		fmt.Println("Expected nil object")
 		t.Fail()
 	}
+}
+
+func TestCancelledGameContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Request should not have been sent")
+	}))
+	defer ts.Close()
+
+	mockURL := ts.URL
+	client := NewCustomClient(&HTTPClient{baseURL: mockURL})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resp, err := client.SearchGamesContext(ctx, "bugsnax")
+	if err == nil {
+		t.Fatal("Expected error for cancelled context")
+	}
 	if resp != nil {
 		fmt.Println("Expected nil object")
 		t.Fail()
 	}
 }
+
+func TestNoMoreGamePages(t *testing.T) {
+	res, err := makeGameCall("testdata/games/basic_response.html", &HLTBQuery{Query: "pokemon red"})
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if _, err := res.GetNextPage(); !errors.Is(err, ErrNoMorePages) {
+		fmt.Printf("Got %v, expected ErrNoMorePages", err)
+		t.Fail()
+	}
+}
+
+func TestUnexpectedGameLayout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "<html><body><p>Down for maintenance</p></body></html>")
+	}))
+	defer ts.Close()
+
+	mockURL := ts.URL
+	client := NewCustomClient(&HTTPClient{baseURL: mockURL})
+
+	_, err := client.SearchGames("bugsnax")
+	var layoutErr *LayoutError
+	if !errors.As(err, &layoutErr) || !errors.Is(err, ErrLayoutChanged) {
+		t.Fatalf("Got %v, expected LayoutError", err)
+	}
+	if layoutErr.Page != 1 {
+		fmt.Printf("Got %v, expected 1", layoutErr.Page)
+		t.Fail()
+	}
+}
+
+func TestMissingGameID(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `<h3>We Found 1 Games</h3><ul><li class="back_darkish"><h3><a href="game">Doom</a></h3></li></ul>`)
+	}))
+	defer ts.Close()
+
+	mockURL := ts.URL
+	client := NewCustomClient(&HTTPClient{baseURL: mockURL})
+
+	_, err := client.SearchGames("doom")
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Selector != "h3 > a" {
+		fmt.Printf("Got %v, expected ParseError for the game link", err)
+		t.Fail()
+	}
+}
+
+func TestUnknownGameLabels(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/games/basic_response.html")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	page := strings.ReplaceAll(string(data), ">Completionist<", ">Solo<")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, page)
+	}))
+	defer ts.Close()
+
+	var unknown []string
+	client := NewClient(WithBaseURL(ts.URL), WithUnknownLabelHandler(func(queryType QueryType, label, value string) {
+		unknown = append(unknown, label)
+	}))
+	res, err := client.SearchGames("pokemon red blue")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	game := res.Games[0]
+	if game.Completionist != "" || game.Extra["Solo"] == "" {
+		fmt.Printf("Got %+v, expected unknown label in Extra", game)
+		t.Fail()
+	}
+	if len(unknown) != 2 || unknown[0] != "Solo" {
+		fmt.Printf("Got %v, expected Solo reported for each game", unknown)
+		t.Fail()
+	}
+	if j, _ := res.JSON(); !strings.Contains(j, `"extra"`) {
+		fmt.Printf("Got %v, expected extra in JSON", j)
+		t.Fail()
+	}
+}
+
+func TestGamePageCounts(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/games/multipage.html")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	// Counts with zeros and separators, and a last page link without a number
+	page := strings.Replace(string(data), "We Found 42848 Games", "We Found 42,048 Games", 1)
+	page = strings.Replace(page, ">2143</span>", ">Last</span>", 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, page)
+	}))
+	defer ts.Close()
+
+	mockURL := ts.URL
+	client := NewCustomClient(&HTTPClient{baseURL: mockURL})
+
+	res, err := client.SearchGames("")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if res.TotalMatches != 42048 {
+		fmt.Printf("Got %v, expected 42048", res.TotalMatches)
+		t.Fail()
+	}
+	if res.TotalPages != 2143 {
+		fmt.Printf("Got %v, expected 2143", res.TotalPages)
+		t.Fail()
+	}
+}
+
+func TestGamePageCountsInvalid(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/games/multipage.html")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	// A decimal count must not be read as 15
+	page := strings.Replace(string(data), "We Found 42848 Games", "We Found 1.5 Games", 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, page)
+	}))
+	defer ts.Close()
+
+	client := NewCustomClient(&HTTPClient{baseURL: ts.URL})
+	res, err := client.SearchGames("")
+	if err == nil {
+		fmt.Printf("Got %v matches, expected error", res.TotalMatches)
+		t.Fail()
+	}
+}
+
+func TestGamePageLinkArgs(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/games/multipage.html")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	// Only the second argument of globalSearch is the page
+	page := strings.Replace(string(data), "globalSearch('games','2143','','','','')", "globalSearch('games','2143','','','','9999')", 1)
+	page = strings.Replace(page, ">2143</span>", ">Last</span>", 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, page)
+	}))
+	defer ts.Close()
+
+	client := NewCustomClient(&HTTPClient{baseURL: ts.URL})
+	res, err := client.SearchGames("")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if res.TotalPages != 2143 {
+		fmt.Printf("Got %v, expected 2143", res.TotalPages)
+		t.Fail()
+	}
+}
@@ -2,4 +2,7 @@ module github.com/fuzzylimes/gohltb
 
 go 1.15
 
-require github.com/PuerkitoBio/goquery v1.6.0
+require (
+	github.com/PuerkitoBio/goquery v1.6.0
+	github.com/andybalholm/cascadia v1.1.0
+)
@@ -1,8 +1,12 @@
 package gohltb
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -13,15 +17,9 @@ import (
 	"github.com/PuerkitoBio/goquery"
 )
 
-var client *http.Client
-
-func init() {
-	client = &http.Client{
-		Timeout: time.Second * 10,
-	}
-}
-
 const (
+	// defaultTimeout is the timeout of the http.Client created for each client
+	defaultTimeout = time.Second * 10
 	// queryURL is the endpoint for queries
 	queryURL string = "https://howlongtobeat.com"
 	// urlPrefix is the base URL
@@ -32,7 +30,14 @@ const (
 // be creating a client via one of the two "New" methods, either NewDefaultClient
 // or NewCustomClient. Either will be needed to perform game or user queries.
 type HLTBClient struct {
-	Client *HTTPClient
+	Client         *HTTPClient                                    // Connectivity details used for each request
+	Limiter        *RateLimiter                                   // Optional limit on the rate of requests, including pagination
+	Cache          Cache                                          // Optional cache serving repeated queries without a request
+	SearchAPI      SearchAPI                                      // Which of the site's endpoints searches are run against
+	Backend        Backend                                        // Optional replacement for howlongtobeat.com as the source of all results
+	DumpHTML       bool                                           // Include the page's HTML in any LayoutError
+	Selectors      *Selectors                                     // Optional override of how pages are scraped, see DefaultSelectors
+	OnUnknownLabel func(queryType QueryType, label, value string) // Optional report of unrecognised detail labels, must be safe for concurrent use
 }
 
 // HTTPClient handles the connectivity details for the client. This is handled
@@ -40,9 +45,15 @@ type HLTBClient struct {
 // be supplied when creating a NewCustomClient. This allows the user to create
 // their own http.Client and pass it in for use. This can be useful if you want
 // to use something other than the default configuration.
+//
+// Failed requests can be retried by setting Retry to a RetryPolicy, such as the
+// one returned by DefaultRetryPolicy.
 type HTTPClient struct {
-	Client  *http.Client
-	baseURL string
+	Client    *http.Client
+	Retry     *RetryPolicy
+	baseURL   string
+	userAgent string
+	header    http.Header
 }
 
 // Pages are a type of data structure for paged responses.
@@ -73,41 +84,120 @@ type HLTBQuery struct {
 
 // NewDefaultClient will create a new HLTBClient with default parameters. This
 // is what you should use to create you client if you don't need to use a specific
-// http.Client/settings
+// http.Client/settings. It is the same as NewClient with no options.
 func NewDefaultClient() *HLTBClient {
-	return &HLTBClient{
-		Client: &HTTPClient{
-			Client:  client,
-			baseURL: queryURL,
-		},
-	}
+	return NewClient()
 }
 
 // NewCustomClient will create a new HLTBClient with provided HTTPClient. Users
 // are able to pass in an HTTPClient with their desired http.Client.
 func NewCustomClient(c *HTTPClient) *HLTBClient {
+	h := NewClient()
 	if c.baseURL == "" {
-		c.baseURL = queryURL
+		c.baseURL = h.Client.baseURL
 	}
 	if c.Client == nil {
-		c.Client = client
-	}
-	return &HLTBClient{
-		Client: c,
+		c.Client = h.Client.Client
 	}
+	h.Client = c
+	return h
 }
 
 // searchQuery is a general helper method used by both Game and User queries. It
 // handles the common activies shared between both query types. This is where
-// data is scraped from howlongtobeat.com
-func searchQuery(c *HLTBClient, q *HLTBQuery) (*goquery.Document, error) {
-	form := buildForm(q)
+// data is scraped from howlongtobeat.com. The request is bound to ctx, so
+// cancelling ctx will abort any in-flight request.
+func searchQuery(ctx context.Context, c *HLTBClient, q *HLTBQuery, parse func(doc *goquery.Document) error) error {
+	form := buildForm(q).Encode()
+	endpoint := fmt.Sprintf("%v/search_results?page=%v", c.Client.baseURL, q.Page)
+	return fetchDocument(ctx, c, "POST", endpoint, form, parse)
+}
+
+// fetchDocument retrieves a page from howlongtobeat.com and passes it to parse.
+// An empty form sends no body. When the client's DumpHTML is set, any
+// LayoutError from parse is given the page exactly as it was received.
+func fetchDocument(ctx context.Context, c *HLTBClient, method, endpoint, form string, parse func(doc *goquery.Document) error) error {
+	var contentType string
+	if form != "" {
+		contentType = "application/x-www-form-urlencoded"
+	}
+	return fetch(ctx, c, method, endpoint, contentType, form, func(body []byte) error {
+		doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		err = parse(doc)
+		var layoutErr *LayoutError
+		if c.DumpHTML && errors.As(err, &layoutErr) {
+			layoutErr.HTML = string(body)
+		}
+		return err
+	})
+}
+
+// fetch retrieves the body of a response from howlongtobeat.com and passes it
+// to parse. Failed requests are retried according to the client's RetryPolicy,
+// and responses are served from the client's Cache when one is set. A response
+// is only cached once parse accepts it, so an error page that comes back with a
+// 200, such as a maintenance page, is requested again next time.
+func fetch(ctx context.Context, c *HLTBClient, method, endpoint, contentType, reqBody string, parse func(body []byte) error) error {
+	key := endpoint
+	if reqBody != "" {
+		key += "&" + reqBody
+	}
+
+	if c.Cache != nil {
+		if body, ok := c.Cache.Get(key); ok {
+			return parse(body)
+		}
+	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%v/search_results?page=%v", c.Client.baseURL, q.Page), strings.NewReader(form.Encode()))
+	var body []byte
+	err := withRetries(ctx, c.Client.Retry, func() error {
+		var err error
+		body, err = doRequest(ctx, c, method, endpoint, contentType, reqBody)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := parse(body); err != nil {
+		return err
+	}
+	if c.Cache != nil {
+		c.Cache.Set(key, body)
+	}
+	return nil
+}
+
+// doRequest makes a single attempt at a request, waiting on the client's rate
+// limiter (if any) before the request is sent. Returns the body of the response.
+func doRequest(ctx context.Context, c *HLTBClient, method, endpoint, contentType, reqBody string) ([]byte, error) {
+	if c.Limiter != nil {
+		if err := c.Limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var r io.Reader
+	if reqBody != "" {
+		r = strings.NewReader(reqBody)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, r)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	for name, values := range c.Client.header {
+		for _, v := range values {
+			req.Header.Add(name, v)
+		}
+	}
+	if c.Client.userAgent != "" {
+		req.Header.Set("User-Agent", c.Client.userAgent)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
 	resp, err := c.Client.Client.Do(req)
 	if err != nil {
 		return nil, err
@@ -115,14 +205,16 @@ func searchQuery(c *HLTBClient, q *HLTBQuery) (*goquery.Document, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, errors.New("Error retrieving data")
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, bodySnippetLength))
+		return nil, &HTTPStatusError{
+			StatusCode: resp.StatusCode,
+			URL:        endpoint,
+			Body:       string(body),
+			retryAfter: parseRetryAfter(resp),
+		}
 	}
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-	return doc, nil
+	return ioutil.ReadAll(resp.Body)
 }
 
 // buildForm will construct the form payload sent to the server. The query
@@ -153,37 +245,115 @@ func buildForm(q *HLTBQuery) url.Values {
 }
 
 // hasResults checks to see if the query has any matches. If no matches found,
-// returns false
-func hasResults(doc *goquery.Document) bool {
-	return !strings.Contains(doc.Find("li").Nodes[0].FirstChild.Data, "No results")
+// returns false. A page without the message element, such as a maintenance or
+// captcha page, returns a *LayoutError.
+func hasResults(doc *goquery.Document, p int, sel *Selectors) (bool, error) {
+	message := doc.Find(sel.Message)
+	if message.Length() == 0 || message.Nodes[0].FirstChild == nil {
+		return false, &LayoutError{Selectors: []string{sel.Message}, Page: p}
+	}
+	return !strings.Contains(message.Nodes[0].FirstChild.Data, sel.NoResults), nil
 }
 
+// pageCallRegex matches the function called by a page link's onclick and its
+// arguments, i.e. globalSearch('games','2143','','','','')
+var pageCallRegex = regexp.MustCompile(`(\w+)\((.*)\)`)
+
+// pageArgRegex matches each quoted argument of a page link's onclick
+var pageArgRegex = regexp.MustCompile(`'((?:[^'\\]|\\.)*)'`)
+
+// pageArgs is the position of the page among the arguments of each function
+// called by page links: globalSearch(type, page, ...) on search results and
+// userGames(user, list, page) on user game lists
+var pageArgs = map[string]int{"globalSearch": 1, "userGames": 2}
+
 // parsePages is a general helper function that handles collecting:
-//    * the total number of matches (only available when querying the first page)
+//    * the total number of matches (only available when querying the first page,
+//      GetNextPage carries it forward to later pages)
 //    * the total number of pages
 // Utilized for both game and user queries
-func parsePages(doc *goquery.Document, page Pages, p int) {
+func parsePages(doc *goquery.Document, page Pages, p int, sel *Selectors) error {
 	if p == 1 {
-		// regex to extract number of games/users
-		regex := regexp.MustCompile(`Found ([1-9]+)`)
-		numMatches := regex.FindStringSubmatch(doc.Find("h3").Nodes[0].FirstChild.Data)[1]
-		numMatchesInt, err := strconv.Atoi(numMatches)
-		if err != nil {
-			// All pages after 1 will be set to 0, this is a TODO
-			page.setTotalMatches(0)
-		} else {
-			page.setTotalMatches(numMatchesInt)
+		header := doc.Find(sel.Header)
+		if header.Length() == 0 {
+			return &ParseError{Selector: sel.Header, Page: p}
 		}
+		matches, ok := parseMatchCount(header.First().Text(), sel)
+		if !ok {
+			return &ParseError{Selector: sel.Header, Page: p, Err: errors.New("match count not found")}
+		}
+		page.setTotalMatches(matches)
 	}
 
-	// scan page for the last page element and convert from string to int
-	lastPage, err := strconv.Atoi(doc.Find("span.search_list_page").Last().Text())
+	page.setTotalPages(parseTotalPages(doc, sel))
+	return nil
+}
+
+// parseMatchCount reads the number of matches from the text of the header
+// using the MatchCount of sel, i.e. 42048 from "We Found 42,048 Games". Commas
+// are read as thousands separators, and any other character fails the parse.
+func parseMatchCount(s string, sel *Selectors) (int, bool) {
+	re, err := matchCountRegex(sel)
 	if err != nil {
-		// if we can't find it, set it to 1
-		page.setTotalPages(1)
-	} else {
-		page.setTotalPages(lastPage)
+		return 0, false
+	}
+	found := re.FindStringSubmatch(s)
+	if found == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.ReplaceAll(found[1], ",", ""))
+	return n, err == nil
+}
+
+// parseTotalPages finds the highest page number among the page links. Each
+// link's page is read from its onclick, falling back to the link's text for
+// the current page, which has no onclick. Pages without page elements have a
+// single page.
+func parseTotalPages(doc *goquery.Document, sel *Selectors) int {
+	total := 1
+	doc.Find(sel.Page).Each(func(i int, link *goquery.Selection) {
+		n, err := strconv.Atoi(strings.TrimSpace(link.Text()))
+		if onclick, ok := link.Attr("onclick"); ok {
+			if page, ok := parsePageLink(onclick); ok {
+				n, err = page, nil
+			}
+		}
+		if err == nil && n > total {
+			total = n
+		}
+	})
+	return total
+}
+
+// parsePageLink reads the page from the onclick of a page link, using the
+// position of the page among the arguments of the function it calls, i.e. 2143
+// from globalSearch('games','2143','','','',''). Returns false if the function
+// isn't known or the page isn't a number.
+func parsePageLink(onclick string) (int, bool) {
+	call := pageCallRegex.FindStringSubmatch(onclick)
+	if call == nil {
+		return 0, false
+	}
+	pos, ok := pageArgs[call[1]]
+	if !ok {
+		return 0, false
+	}
+	args := pageArgRegex.FindAllStringSubmatch(call[2], -1)
+	if pos >= len(args) {
+		return 0, false
+	}
+	n, err := strconv.Atoi(args[pos][1])
+	return n, err == nil
+}
+
+// parseID extracts the ID from a link to a game or user page, i.e. "57506"
+// from "game?id=57506". Returns false if the link has no ID.
+func parseID(href, prefix string) (string, bool) {
+	i := strings.Index(href, prefix)
+	if i < 0 || i+len(prefix) == len(href) {
+		return "", false
 	}
+	return href[i+len(prefix):], true
 }
 
 // sanitizeTitle will remove any unexpected characters from a game title
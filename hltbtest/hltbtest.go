@@ -0,0 +1,642 @@
+// Package hltbtest provides a fake howlongtobeat.com server for testing code
+// that uses gohltb without making requests to the real site.
+//
+// The server answers searches in the same form as howlongtobeat.com, either
+// from canned fixtures or from games and users added to the server. Searches
+// against added records honor the page requested along with the query string,
+// platform, sort, length and modifier fields of the query. Both the HTML search
+// and the JSON search API are served.
+//
+// Game pages, user profiles and user game lists are served from fixtures, such
+// as the pages saved in the gohltb repository's testdata directory. The server
+// can also be made to fail or respond slowly.
+//
+// example:
+//
+//	srv := hltbtest.NewServer()
+//	defer srv.Close()
+//	srv.AddGame(&gohltb.GameResult{ID: "1", Title: "Doom", Main: "12 Hours"}, gohltb.PC)
+//	res, err := srv.Client().SearchGames("doom")
+package hltbtest
+
+import (
+	"html/template"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fuzzylimes/gohltb"
+)
+
+// DefaultPageSize is the number of results on each page of a search, matching
+// howlongtobeat.com.
+const DefaultPageSize = 20
+
+// Request is a search request received by the server
+type Request struct {
+	Page int        // Page requested
+	Form url.Values // Form sent with the request
+}
+
+// Server is a fake howlongtobeat.com server. Create one with NewServer, and
+// Close it when done.
+type Server struct {
+	URL string // Base URL of the server, i.e. http://127.0.0.1:1234
+
+	mu         sync.Mutex
+	srv        *httptest.Server
+	games      []*game
+	users      []*gohltb.UserResult
+	fixtures   map[fixtureKey][]byte
+	pageSize   int
+	latency    time.Duration
+	failCount  int
+	failStatus int
+	requests   []Request
+}
+
+// game is a game added to the server, along with the platforms it is on
+type game struct {
+	*gohltb.GameResult
+	platforms []gohltb.Platform
+}
+
+// fixtureKey identifies the request a fixture is served for
+type fixtureKey struct {
+	path string // Path of the endpoint, i.e. /search_results
+	name string // Query type, game ID or user the request is for
+	page int
+}
+
+// Paths of the endpoints served by the server
+const (
+	searchPath    = "/search_results"
+	jsonPath      = "/api/search"
+	gamePath      = "/game"
+	userPath      = "/user"
+	userGamesPath = "/user_games"
+)
+
+// NewServer starts a fake server with no games or users
+func NewServer() *Server {
+	s := &Server{
+		fixtures: make(map[fixtureKey][]byte),
+		pageSize: DefaultPageSize,
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.handle))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns a HLTBClient that sends all of its requests to the server.
+// The client can be configured further like any other, i.e. with a Cache.
+func (s *Server) Client() *gohltb.HLTBClient {
+	target, _ := url.Parse(s.URL)
+	return gohltb.NewCustomClient(&gohltb.HTTPClient{
+		Client: &http.Client{
+			Transport: &rewriteTransport{target: target, base: s.srv.Client().Transport},
+		},
+	})
+}
+
+// AddGame adds a game to the server, which is available on the provided
+// platforms. The displayed times, i.e. Main, are served as they are set.
+func (s *Server) AddGame(g *gohltb.GameResult, platforms ...gohltb.Platform) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.games = append(s.games, &game{GameResult: g, platforms: platforms})
+}
+
+// AddUser adds a user to the server
+func (s *Server) AddUser(u *gohltb.UserResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users = append(s.users, u)
+}
+
+// SetFixture serves body for every search of queryType on the given page,
+// in place of any added games or users.
+func (s *Server) SetFixture(queryType gohltb.QueryType, page int, body []byte) {
+	s.setFixture(fixtureKey{searchPath, string(queryType), page}, body)
+}
+
+// LoadFixture is the same as SetFixture, but reads the body from a file
+func (s *Server) LoadFixture(queryType gohltb.QueryType, page int, file string) error {
+	return loadFile(file, func(body []byte) { s.SetFixture(queryType, page, body) })
+}
+
+// SetJSONFixture serves body for every search of queryType on the given page
+// made through the JSON search API, in place of any added games or users.
+func (s *Server) SetJSONFixture(queryType gohltb.QueryType, page int, body []byte) {
+	s.setFixture(fixtureKey{jsonPath, string(queryType), page}, body)
+}
+
+// LoadJSONFixture is the same as SetJSONFixture, but reads the body from a file
+func (s *Server) LoadJSONFixture(queryType gohltb.QueryType, page int, file string) error {
+	return loadFile(file, func(body []byte) { s.SetJSONFixture(queryType, page, body) })
+}
+
+// SetGameFixture serves body as the page of the game with the provided ID
+func (s *Server) SetGameFixture(id string, body []byte) {
+	s.setFixture(fixtureKey{gamePath, id, 0}, body)
+}
+
+// LoadGameFixture is the same as SetGameFixture, but reads the body from a file
+func (s *Server) LoadGameFixture(id string, file string) error {
+	return loadFile(file, func(body []byte) { s.SetGameFixture(id, body) })
+}
+
+// SetUserFixture serves body as the profile page of the named user
+func (s *Server) SetUserFixture(name string, body []byte) {
+	s.setFixture(fixtureKey{userPath, name, 0}, body)
+}
+
+// LoadUserFixture is the same as SetUserFixture, but reads the body from a file
+func (s *Server) LoadUserFixture(name string, file string) error {
+	return loadFile(file, func(body []byte) { s.SetUserFixture(name, body) })
+}
+
+// SetUserGamesFixture serves body as the given page of one of the named user's
+// lists of games
+func (s *Server) SetUserGamesFixture(name string, list gohltb.UserList, page int, body []byte) {
+	s.setFixture(fixtureKey{userGamesPath, name + "/" + string(list), page}, body)
+}
+
+// LoadUserGamesFixture is the same as SetUserGamesFixture, but reads the body
+// from a file
+func (s *Server) LoadUserGamesFixture(name string, list gohltb.UserList, page int, file string) error {
+	return loadFile(file, func(body []byte) { s.SetUserGamesFixture(name, list, page, body) })
+}
+
+// setFixture serves body for every request matching key
+func (s *Server) setFixture(key fixtureKey, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fixtures[key] = body
+}
+
+// loadFile reads file and passes its contents to set
+func loadFile(file string, set func(body []byte)) error {
+	body, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	set(body)
+	return nil
+}
+
+// SetPageSize sets the number of results on each page of a search
+func (s *Server) SetPageSize(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pageSize = n
+}
+
+// SetLatency delays every response by d. Requests that are cancelled while
+// waiting receive no response.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// FailNext responds to the next n requests with status instead of results. A
+// negative n fails all requests until FailNext is called again.
+func (s *Server) FailNext(n int, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failCount = n
+	s.failStatus = status
+}
+
+// Requests returns the search requests received by the server, in order.
+// Searches made through the JSON search API are included, with the query
+// converted into the fields of the HTML search form.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// handle responds to a single request made to the server
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	// Read the form up front, so cancelled requests are noticed while waiting
+	formErr := r.ParseForm()
+
+	s.mu.Lock()
+	latency := s.latency
+	status := 0
+	if s.failCount != 0 {
+		status = s.failStatus
+		if s.failCount > 0 {
+			s.failCount--
+		}
+	}
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if status != 0 {
+		w.WriteHeader(status)
+		return
+	}
+	if formErr != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	switch r.URL.Path {
+	case searchPath:
+		s.serveSearch(w, page, r.PostForm)
+	case jsonPath:
+		s.serveJSONSearch(w, r)
+	case gamePath:
+		s.serveFixture(w, fixtureKey{gamePath, r.URL.Query().Get("id"), 0})
+	case userPath:
+		s.serveFixture(w, fixtureKey{userPath, r.URL.Query().Get("n"), 0})
+	case userGamesPath:
+		name := r.URL.Query().Get("n") + "/" + r.URL.Query().Get("s")
+		s.serveFixture(w, fixtureKey{userGamesPath, name, page})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// serveSearch responds to a search made through the HTML search
+func (s *Server) serveSearch(w http.ResponseWriter, page int, form url.Values) {
+	queryType := gohltb.QueryType(form.Get("t"))
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Page: page, Form: form})
+	fixture, ok := s.fixtures[fixtureKey{searchPath, string(queryType), page}]
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if ok {
+		w.Write(fixture)
+		return
+	}
+	s.search(w, queryType, page, form)
+}
+
+// serveFixture responds with the fixture for key, or a 404 if there isn't one
+func (s *Server) serveFixture(w http.ResponseWriter, key fixtureKey) {
+	s.mu.Lock()
+	fixture, ok := s.fixtures[key]
+	s.mu.Unlock()
+
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(fixture)
+}
+
+// search renders a page of the games or users matching the form
+func (s *Server) search(w http.ResponseWriter, queryType gohltb.QueryType, page int, form url.Values) {
+	results, ok := s.results(queryType, page, form)
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	data := &resultsPage{
+		QueryType:  string(queryType),
+		Query:      form.Get("queryString"),
+		Page:       page,
+		Matches:    results.matches,
+		TotalPages: results.totalPages,
+		Results:    results.page,
+		UserStats:  form.Get("detail") == string(gohltb.ShowUserStats),
+	}
+	for p := 1; p <= data.TotalPages; p++ {
+		data.Pages = append(data.Pages, p)
+	}
+	resultsTemplate.Execute(w, data)
+}
+
+// searchResults are the games or users on a page of a search
+type searchResults struct {
+	page       []interface{} // *game or *gohltb.UserResult on the requested page
+	matches    int
+	totalPages int
+}
+
+// results finds the page of games or users matching the form. Searches for an
+// unknown query type return false.
+func (s *Server) results(queryType gohltb.QueryType, page int, form url.Values) (*searchResults, bool) {
+	var results []interface{}
+	switch queryType {
+	case gohltb.GameQuery:
+		for _, g := range s.matchingGames(form) {
+			results = append(results, g)
+		}
+	case gohltb.UserQuery:
+		for _, u := range s.matchingUsers(form) {
+			results = append(results, u)
+		}
+	default:
+		return nil, false
+	}
+	if form.Get("randomize") == "1" && len(results) > 1 {
+		results = results[:1]
+	}
+
+	s.mu.Lock()
+	size := s.pageSize
+	s.mu.Unlock()
+	if size < 1 {
+		size = DefaultPageSize
+	}
+	res := &searchResults{
+		matches:    len(results),
+		totalPages: (len(results) + size - 1) / size,
+	}
+	if start := (page - 1) * size; start < len(results) {
+		end := start + size
+		if end > len(results) {
+			end = len(results)
+		}
+		res.page = results[start:end]
+	}
+	return res, true
+}
+
+// matchingGames filters and sorts the server's games by the form
+func (s *Server) matchingGames(form url.Values) []*game {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	query := strings.ToLower(form.Get("queryString"))
+	platform := gohltb.Platform(form.Get("plat"))
+	lengthType := gohltb.LengthRange(form.Get("length_type"))
+	min, minErr := strconv.ParseFloat(form.Get("length_min"), 64)
+	max, maxErr := strconv.ParseFloat(form.Get("length_max"), 64)
+
+	var games []*game
+	for _, g := range s.games {
+		if !strings.Contains(strings.ToLower(g.Title), query) {
+			continue
+		}
+		if platform != "" && !hasPlatform(g.platforms, platform) {
+			continue
+		}
+		if minErr == nil || maxErr == nil {
+			hours, ok := gameHours(g, gohltb.SortBy(lengthType))
+			if !ok || (minErr == nil && hours < min) || (maxErr == nil && hours > max) {
+				continue
+			}
+		}
+		games = append(games, g)
+	}
+
+	sortBy := gohltb.SortBy(form.Get("sorthead"))
+	sort.SliceStable(games, func(i, j int) bool {
+		if sortBy == gohltb.SortByGameName {
+			return strings.ToLower(games[i].Title) < strings.ToLower(games[j].Title)
+		}
+		a, aok := gameHours(games[i], sortBy)
+		b, bok := gameHours(games[j], sortBy)
+		return aok && (!bok || a < b)
+	})
+	if gohltb.SortDirection(form.Get("sortd")) == gohltb.ReverseOrder {
+		for i, j := 0, len(games)-1; i < j; i, j = i+1, j-1 {
+			games[i], games[j] = games[j], games[i]
+		}
+	}
+	return games
+}
+
+// matchingUsers filters and sorts the server's users by the form
+func (s *Server) matchingUsers(form url.Values) []*gohltb.UserResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	query := strings.ToLower(form.Get("queryString"))
+
+	var users []*gohltb.UserResult
+	for _, u := range s.users {
+		if strings.Contains(strings.ToLower(u.Name), query) {
+			users = append(users, u)
+		}
+	}
+	if gohltb.SortBy(form.Get("sorthead")) == gohltb.SortByUserName {
+		sort.SliceStable(users, func(i, j int) bool {
+			return strings.ToLower(users[i].Name) < strings.ToLower(users[j].Name)
+		})
+	}
+	if gohltb.SortDirection(form.Get("sortd")) == gohltb.ReverseOrder {
+		for i, j := 0, len(users)-1; i < j; i, j = i+1, j-1 {
+			users[i], users[j] = users[j], users[i]
+		}
+	}
+	return users
+}
+
+// gameHours returns the game's time in hours for a time based sort or length
+// filter. Sorts without a time return false.
+func gameHours(g *game, by gohltb.SortBy) (float64, bool) {
+	var t gohltb.CompletionTime
+	switch by {
+	case gohltb.SortByGameMainStory:
+		t = g.MainTime()
+	case gohltb.SortByGameMainExtras:
+		t = g.MainExtraTime()
+	case gohltb.SortByGameCompletionist:
+		t = g.CompletionistTime()
+	default:
+		return 0, false
+	}
+	d, ok := t.Duration()
+	return d.Hours(), ok
+}
+
+// hasPlatform checks if p is in platforms
+func hasPlatform(platforms []gohltb.Platform, p gohltb.Platform) bool {
+	for _, platform := range platforms {
+		if platform == p {
+			return true
+		}
+	}
+	return false
+}
+
+// rewriteTransport sends every request to the target server
+type rewriteTransport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+// RoundTrip for http.RoundTripper interface
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	r.Host = ""
+	return t.base.RoundTrip(r)
+}
+
+// resultsPage is the data rendered by resultsTemplate
+type resultsPage struct {
+	QueryType  string
+	Query      string
+	Page       int
+	Matches    int
+	TotalPages int
+	Pages      []int
+	Results    []interface{}
+	UserStats  bool
+}
+
+// resultsTemplate renders a page of search results in the same form as
+// howlongtobeat.com
+var resultsTemplate = template.Must(template.New("results").Parse(`
+{{- if not .Results -}}
+<li class='global_padding back_primary shadow_box'>No results for
+    <strong>{{.Query}}</strong> in
+    <u>{{.QueryType}}</u>.
+</li>
+<div class='clear'></div>
+{{- else -}}
+{{- $root := . -}}
+{{- if eq .Page 1}}
+<h3 class='global_padding shadow_box back_blue center'>We Found {{.Matches}} {{if eq .QueryType "games"}}Games{{else}}Users{{end}}</h3>
+{{- else}}
+<h3 class='global_padding shadow_box back_blue center'> Page {{.Page}}</h3>
+{{- end}}
+<ul>
+{{- range .Results}}
+    <div class="clear"></div>
+    {{- if eq $root.QueryType "games"}}
+    <li class="back_darkish">
+        <div class="search_list_image">
+            <a title="{{.Title}}" href="game?id={{.ID}}">
+                <img alt="Box Art" src="{{.BoxArtURL}}" />
+            </a>
+        </div>
+        <div class="search_list_details">
+            <h3 class="shadow_text">
+                <a class="text_white" title="{{.Title}}" href="game?id={{.ID}}">{{.Title}}</a>
+            </h3>
+            <div class="search_list_details_block">
+            {{- if .Other}}
+            {{- range $label, $time := .Other}}
+                <div class="search_list_tidbit_short text_white shadow_text">{{$label}}</div>
+                <div class="search_list_tidbit_long center">{{$time}}</div>
+            {{- end}}
+            {{- else}}
+                <div>
+                    {{- if or .Main (not (or .MainExtra .Completionist))}}
+                    <div class="search_list_tidbit text_white shadow_text">Main Story</div>
+                    <div class="search_list_tidbit center">{{if .Main}}{{.Main}}{{else}}--{{end}}</div>
+                    {{- end}}
+                    {{- if .MainExtra}}
+                    <div class="search_list_tidbit text_white shadow_text">Main + Extra</div>
+                    <div class="search_list_tidbit center">{{.MainExtra}}</div>
+                    {{- end}}
+                    {{- if .Completionist}}
+                    <div class="search_list_tidbit text_white shadow_text">Completionist</div>
+                    <div class="search_list_tidbit center">{{.Completionist}}</div>
+                    {{- end}}
+                </div>
+                {{- if and $root.UserStats .UserStats}}
+                {{- with .UserStats}}
+                <div class="search_list_tidbit text_white shadow_text">Polled</div>
+                <div class="search_list_tidbit center back_primary">{{.Completed}}</div>
+                <div class="search_list_tidbit text_white shadow_text">Rated</div>
+                <div class="search_list_tidbit center back_primary">{{.Rating}}</div>
+                <div class="search_list_tidbit text_white shadow_text">Backlog</div>
+                <div class="search_list_tidbit center back_primary">{{.Backlog}}</div>
+                <div class="search_list_tidbit text_white shadow_text">Playing</div>
+                <div class="search_list_tidbit center back_primary">{{.Playing}}</div>
+                <div class="search_list_tidbit text_white shadow_text">Speedruns</div>
+                <div class="search_list_tidbit center back_primary">{{.SpeedRuns}}</div>
+                <div class="search_list_tidbit text_white shadow_text">Retired</div>
+                <div class="search_list_tidbit center back_primary">{{.Retired}}</div>
+                {{- end}}
+                {{- end}}
+            {{- end}}
+            </div>
+        </div>
+    </li>
+    {{- else}}
+    <li class="back_darkish">
+        <div class="search_list_image">
+            <a title="{{.ID}}" href="user?n={{.ID}}">
+                <img src="{{if .AvatarURL}}{{.AvatarURL}}{{else}}avatars/no_avatar.png{{end}}" />
+            </a>
+        </div>
+        <div class="search_list_details">
+            <h3 class="shadow_text">
+                <a class="text_white" title="{{.ID}}" href="user?n={{.ID}}">{{.Name}}</a>
+                {{- if .Accolades}}
+                <span>
+                {{- range .Accolades}}
+                    <span title='{{.}}'>{{.}}</span>
+                {{- end}}
+                </span>
+                {{- end}}
+            </h3>
+            {{- if .Location}}
+            <h4 class='back_secondary'>{{.Location}}</h4>
+            {{- end}}
+            <div class="search_list_details_block">
+                {{- if .Backlog}}
+                <div class="search_list_tidbit text_white shadow_text">Backlog</div>
+                <div class='search_list_tidbit center back_blue'>{{.Backlog}}</div>
+                {{- end}}
+                {{- if .Complete}}
+                <div class="search_list_tidbit text_white shadow_text">Complete</div>
+                <div class='search_list_tidbit center back_blue'>{{.Complete}}</div>
+                {{- end}}
+                {{- if .Gender}}
+                <div class="search_list_tidbit text_white shadow_text">Gender</div>
+                <div class='search_list_tidbit center back_orange'>{{.Gender}}</div>
+                {{- end}}
+                {{- if .Age}}
+                <div class="search_list_tidbit text_white shadow_text">Age</div>
+                <div class='search_list_tidbit center back_red'>{{.Age}}</div>
+                {{- end}}
+                {{- if .Posts}}
+                <div class="search_list_tidbit text_white shadow_text">Posts</div>
+                <div class='search_list_tidbit center back_green'>{{.Posts}}</div>
+                {{- end}}
+            </div>
+        </div>
+    </li>
+    {{- end}}
+{{- end}}
+    <div class="clear"></div>
+</ul>
+<div class="clear"></div>
+{{- if gt .TotalPages 1}}
+<div class='search_list_pages'>
+{{- range .Pages}}
+    {{- if eq . $root.Page}}
+    <span class='search_list_page back_blue shadow_box'>{{.}}</span>
+    {{- else}}
+    <span class="search_list_page back_secondary shadow_box" onclick="globalSearch('{{$root.QueryType}}','{{.}}','','','','');">{{.}}</span>
+    {{- end}}
+{{- end}}
+</div>
+{{- end}}
+{{- end}}
+`))
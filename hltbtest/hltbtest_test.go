@@ -0,0 +1,248 @@
+package hltbtest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fuzzylimes/gohltb"
+	"github.com/fuzzylimes/gohltb/hltbtest"
+)
+
+func TestServerGames(t *testing.T) {
+	srv := hltbtest.NewServer()
+	defer srv.Close()
+	srv.SetPageSize(2)
+	srv.AddGame(&gohltb.GameResult{ID: "1", Title: "Doom", Main: "12 Hours"}, gohltb.PC)
+	srv.AddGame(&gohltb.GameResult{ID: "2", Title: "Doom Eternal", Main: "13½ Hours"}, gohltb.PC, gohltb.PlayStation4)
+	srv.AddGame(&gohltb.GameResult{ID: "3", Title: "Doom 64", Main: "6 Hours"}, gohltb.Nintendo64)
+	srv.AddGame(&gohltb.GameResult{ID: "4", Title: "Fallout 4", Main: "26½ Hours"}, gohltb.PC)
+
+	res, err := srv.Client().SearchGames("doom")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if res.TotalMatches != 3 || res.TotalPages != 2 || len(res.Games) != 2 || res.Games[0].Title != "Doom" {
+		fmt.Printf("Got %v/%v/%v, unexpected first page", res.TotalMatches, res.TotalPages, len(res.Games))
+		t.Fail()
+	}
+	res, err = res.GetNextPage()
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(res.Games) != 1 || res.Games[0].ID != "2" || res.Games[0].Main != "13½ Hours" || res.HasNext() {
+		fmt.Printf("Got %+v, unexpected second page", res.Games)
+		t.Fail()
+	}
+
+	res, err = srv.Client().SearchGamesByQuery(&gohltb.HLTBQuery{
+		Platform:      gohltb.PC,
+		SortBy:        gohltb.SortByGameMainStory,
+		SortDirection: gohltb.ReverseOrder,
+	})
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(res.Games) != 2 || res.Games[0].Title != "Fallout 4" || res.Games[1].Title != "Doom Eternal" {
+		fmt.Printf("Got %+v, expected PC games by longest main story", res.Games)
+		t.Fail()
+	}
+
+	res, err = srv.Client().SearchGamesByQuery(&gohltb.HLTBQuery{LengthMin: "10", LengthMax: "20"})
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if res.TotalMatches != 2 || res.Games[0].Title != "Doom" || res.Games[1].Title != "Doom Eternal" {
+		fmt.Printf("Got %+v, expected games between 10 and 20 hours", res.Games)
+		t.Fail()
+	}
+
+	requests := srv.Requests()
+	if len(requests) != 4 || requests[1].Page != 2 || requests[2].Form.Get("plat") != string(gohltb.PC) {
+		fmt.Printf("Got %+v, unexpected requests", requests)
+		t.Fail()
+	}
+}
+
+func TestServerUsers(t *testing.T) {
+	srv := hltbtest.NewServer()
+	defer srv.Close()
+	srv.AddUser(&gohltb.UserResult{ID: "tiamat911", Name: "tiamat911", Location: "Quebec, Canada", Age: 39, Accolades: []string{"6 Year(s)"}})
+	srv.AddUser(&gohltb.UserResult{ID: "Bob", Name: "Bob", Complete: "12"})
+
+	res, err := srv.Client().SearchUsers("tiamat")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(res.Users) != 1 {
+		t.Fatalf("Got %v, expected 1 user", len(res.Users))
+	}
+	user := res.Users[0]
+	if user.ID != "tiamat911" || user.Location != "Quebec, Canada" || user.Age != 39 || len(user.Accolades) != 1 {
+		fmt.Printf("Got %+v, unexpected user", user)
+		t.Fail()
+	}
+
+	res, err = srv.Client().SearchUsers("nobody")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(res.Users) != 0 {
+		fmt.Printf("Got %v, expected no users", len(res.Users))
+		t.Fail()
+	}
+}
+
+func TestServerFixture(t *testing.T) {
+	srv := hltbtest.NewServer()
+	defer srv.Close()
+	if err := srv.LoadFixture(gohltb.GameQuery, 1, "../testdata/games/basic_response.html"); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	res, err := srv.Client().SearchGames("anything")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(res.Games) != 2 || res.TotalMatches != 2 || res.Games[1].ID != "7169" {
+		fmt.Printf("Got %v games, expected 2 from fixture", len(res.Games))
+		t.Fail()
+	}
+}
+
+func TestServerFailures(t *testing.T) {
+	srv := hltbtest.NewServer()
+	defer srv.Close()
+	srv.FailNext(1, 503)
+
+	_, err := srv.Client().SearchGames("doom")
+	var statusErr *gohltb.HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != 503 {
+		fmt.Printf("Got %v, expected status 503", err)
+		t.Fail()
+	}
+	if _, err := srv.Client().SearchGames("doom"); err != nil {
+		fmt.Printf("Got %v, expected failure to have cleared", err)
+		t.Fail()
+	}
+
+	srv.SetLatency(time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := srv.Client().SearchGamesContext(ctx, "doom"); !errors.Is(err, context.DeadlineExceeded) {
+		fmt.Printf("Got %v, expected deadline exceeded", err)
+		t.Fail()
+	}
+}
+
+func TestServerDetailFixtures(t *testing.T) {
+	srv := hltbtest.NewServer()
+	defer srv.Close()
+	if err := srv.LoadGameFixture("57506", "../testdata/games/detail.html"); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if err := srv.LoadUserFixture("tiamat911", "../testdata/users/profile.html"); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if err := srv.LoadUserGamesFixture("tiamat911", gohltb.ListCompleted, 1, "../testdata/users/games.html"); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	client := srv.Client()
+	ctx := context.Background()
+
+	game, err := client.GetGame(ctx, "57506")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if game.Title != "Doom Eternal" {
+		fmt.Printf("Got %v, expected Doom Eternal", game.Title)
+		t.Fail()
+	}
+	if _, err := client.GetGame(ctx, "1"); err == nil {
+		fmt.Println("Expected an error for a game without a fixture")
+		t.Fail()
+	}
+
+	user, err := client.GetUser(ctx, "tiamat911")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if user.Completed != 169 {
+		fmt.Printf("Got %v, expected 169", user.Completed)
+		t.Fail()
+	}
+
+	games, err := client.GetUserGames(ctx, "tiamat911", gohltb.ListCompleted, 1)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(games.Games) != 2 || games.Games[0].GameID != "57506" {
+		fmt.Printf("Got %+v, expected 2 games from fixture", games.Games)
+		t.Fail()
+	}
+	if _, err := client.GetUserGames(ctx, "tiamat911", gohltb.ListBacklog, 1); err == nil {
+		fmt.Println("Expected an error for a list without a fixture")
+		t.Fail()
+	}
+}
+
+func TestServerJSONSearch(t *testing.T) {
+	srv := hltbtest.NewServer()
+	defer srv.Close()
+	srv.SetPageSize(1)
+	srv.AddGame(&gohltb.GameResult{ID: "1", Title: "Doom", Main: "12 Hours", Completionist: "20½ Hours"}, gohltb.PC)
+	srv.AddGame(&gohltb.GameResult{ID: "2", Title: "Doom 64", Main: "6 Hours"}, gohltb.Nintendo64)
+	srv.AddUser(&gohltb.UserResult{ID: "tiamat911", Name: "tiamat911", Location: "Quebec, Canada", Complete: "169"})
+	client := srv.Client()
+	client.SearchAPI = gohltb.JSONSearchAPI
+
+	res, err := client.SearchGames("doom")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if res.TotalMatches != 2 || res.TotalPages != 2 || len(res.Games) != 1 {
+		fmt.Printf("Got %v/%v/%v, unexpected first page", res.TotalMatches, res.TotalPages, len(res.Games))
+		t.Fail()
+	}
+	if doom := res.Games[0]; doom.ID != "1" || doom.Main != "12 Hours" || doom.Completionist != "20½ Hours" {
+		fmt.Printf("Got %+v, unexpected game", doom)
+		t.Fail()
+	}
+	res, err = res.GetNextPage()
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(res.Games) != 1 || res.Games[0].Title != "Doom 64" {
+		fmt.Printf("Got %+v, unexpected second page", res.Games)
+		t.Fail()
+	}
+
+	users, err := client.SearchUsers("tiamat")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(users.Users) != 1 || users.Users[0].Location != "Quebec, Canada" || users.Users[0].Complete != "169" {
+		fmt.Printf("Got %+v, unexpected users", users.Users)
+		t.Fail()
+	}
+
+	reqs := srv.Requests()
+	if len(reqs) != 3 || reqs[1].Page != 2 || reqs[1].Form.Get("queryString") != "doom" {
+		fmt.Printf("Got %+v, unexpected requests", reqs)
+		t.Fail()
+	}
+
+	if err := srv.LoadJSONFixture(gohltb.GameQuery, 1, "../testdata/games/search.json"); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	res, err = client.SearchGames("anything")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if res.TotalMatches != 42848 || res.Games[0].Title != "Doom Eternal" {
+		fmt.Printf("Got %v/%+v, expected results from fixture", res.TotalMatches, res.Games[0])
+		t.Fail()
+	}
+}
@@ -0,0 +1,217 @@
+package hltbtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/fuzzylimes/gohltb"
+)
+
+// jsonSearchRequest is the body posted to the JSON search API
+type jsonSearchRequest struct {
+	SearchType    string   `json:"searchType"`
+	SearchTerms   []string `json:"searchTerms"`
+	SearchPage    int      `json:"searchPage"`
+	SearchOptions struct {
+		Games struct {
+			Platform      string `json:"platform"`
+			SortCategory  string `json:"sortCategory"`
+			RangeCategory string `json:"rangeCategory"`
+			RangeTime     struct {
+				Min int `json:"min"`
+				Max int `json:"max"`
+			} `json:"rangeTime"`
+			Modifier string `json:"modifier"`
+		} `json:"games"`
+		Users struct {
+			SortCategory string `json:"sortCategory"`
+		} `json:"users"`
+		Sort       int `json:"sort"`
+		Randomizer int `json:"randomizer"`
+	} `json:"searchOptions"`
+}
+
+// jsonSearchResponse is the response from the JSON search API
+type jsonSearchResponse struct {
+	Count       int           `json:"count"`
+	PageCurrent int           `json:"pageCurrent"`
+	PageTotal   int           `json:"pageTotal"`
+	Data        []interface{} `json:"data"`
+}
+
+// jsonGame is a single game in a jsonSearchResponse. Times are in seconds.
+type jsonGame struct {
+	GameID        int    `json:"game_id"`
+	GameName      string `json:"game_name"`
+	GameImage     string `json:"game_image"`
+	CompMain      int    `json:"comp_main"`
+	CompPlus      int    `json:"comp_plus"`
+	Comp100       int    `json:"comp_100"`
+	CompLvlSp     int    `json:"comp_lvl_sp"`
+	CompLvlCo     int    `json:"comp_lvl_co"`
+	CompLvlMp     int    `json:"comp_lvl_mp"`
+	InvestedCo    int    `json:"invested_co"`
+	InvestedMp    int    `json:"invested_mp"`
+	CountComp     int    `json:"count_comp"`
+	CountBacklog  int    `json:"count_backlog"`
+	CountPlaying  int    `json:"count_playing"`
+	CountRetired  int    `json:"count_retired"`
+	CountSpeedrun int    `json:"count_speedrun"`
+	CountReview   int    `json:"count_review"`
+	ReviewScore   int    `json:"review_score"`
+}
+
+// jsonUser is a single user in a jsonSearchResponse
+type jsonUser struct {
+	UserName     string `json:"user_name"`
+	Avatar       string `json:"avatar"`
+	Location     string `json:"location"`
+	Gender       string `json:"gender"`
+	Age          int    `json:"age"`
+	CountPosts   int    `json:"count_posts"`
+	CountBacklog int    `json:"count_backlog"`
+	CountComp    int    `json:"count_comp"`
+}
+
+// serveJSONSearch responds to a search made through the JSON search API
+func (s *Server) serveJSONSearch(w http.ResponseWriter, r *http.Request) {
+	req := &jsonSearchRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	page := req.SearchPage
+	if page < 1 {
+		page = 1
+	}
+	queryType := gohltb.QueryType(req.SearchType)
+	form := req.form()
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Page: page, Form: form})
+	fixture, ok := s.fixtures[fixtureKey{jsonPath, string(queryType), page}]
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if ok {
+		w.Write(fixture)
+		return
+	}
+
+	results, ok := s.results(queryType, page, form)
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	resp := &jsonSearchResponse{
+		Count:       results.matches,
+		PageCurrent: page,
+		PageTotal:   results.totalPages,
+		Data:        []interface{}{},
+	}
+	for _, result := range results.page {
+		switch r := result.(type) {
+		case *game:
+			resp.Data = append(resp.Data, newJSONGame(r.GameResult))
+		case *gohltb.UserResult:
+			resp.Data = append(resp.Data, newJSONUser(r))
+		}
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+// form converts the request into the fields of the HTML search form, so that
+// both searches are filtered the same way
+func (req *jsonSearchRequest) form() url.Values {
+	opts := req.SearchOptions
+	form := url.Values{
+		"t":           {req.SearchType},
+		"queryString": {strings.Join(req.SearchTerms, " ")},
+		"plat":        {opts.Games.Platform},
+		"sorthead":    {opts.Games.SortCategory},
+		"length_type": {opts.Games.RangeCategory},
+		"detail":      {opts.Games.Modifier},
+	}
+	if req.SearchType == string(gohltb.UserQuery) {
+		form.Set("sorthead", opts.Users.SortCategory)
+	}
+	if opts.Games.RangeTime.Min > 0 {
+		form.Set("length_min", strconv.Itoa(opts.Games.RangeTime.Min))
+	}
+	if opts.Games.RangeTime.Max > 0 {
+		form.Set("length_max", strconv.Itoa(opts.Games.RangeTime.Max))
+	}
+	if opts.Sort == 1 {
+		form.Set("sortd", string(gohltb.ReverseOrder))
+	}
+	if opts.Randomizer == 1 {
+		form.Set("randomize", "1")
+	}
+	return form
+}
+
+// newJSONGame converts an added game into the form returned by the JSON
+// search API
+func newJSONGame(g *gohltb.GameResult) *jsonGame {
+	id, _ := strconv.Atoi(g.ID)
+	j := &jsonGame{GameID: id, GameName: g.Title}
+	if g.BoxArtURL != "" {
+		j.GameImage = path.Base(g.BoxArtURL)
+	}
+	if len(g.Other) > 0 {
+		j.CompLvlCo, j.CompLvlMp = 1, 1
+		j.InvestedCo = seconds(g.Other["Co-Op"])
+		j.InvestedMp = seconds(g.Other["Vs."])
+	} else {
+		j.CompLvlSp = 1
+		j.CompMain = seconds(g.Main)
+		j.CompPlus = seconds(g.MainExtra)
+		j.Comp100 = seconds(g.Completionist)
+	}
+	if stats := g.UserStats; stats != nil {
+		j.CountComp, _ = stats.CompletedCount()
+		j.CountBacklog, _ = stats.BacklogCount()
+		j.CountPlaying, _ = stats.PlayingCount()
+		j.CountRetired, _ = stats.RetiredCount()
+		j.CountSpeedrun, _ = stats.SpeedRunsCount()
+		j.CountReview, _ = stats.RatingVotes()
+		rating, _ := stats.RatingPercent()
+		j.ReviewScore = int(rating)
+	}
+	return j
+}
+
+// newJSONUser converts an added user into the form returned by the JSON
+// search API
+func newJSONUser(u *gohltb.UserResult) *jsonUser {
+	j := &jsonUser{
+		UserName: u.ID,
+		Location: u.Location,
+		Gender:   u.Gender,
+		Age:      u.Age,
+	}
+	if j.UserName == "" {
+		j.UserName = u.Name
+	}
+	if u.AvatarURL != "" && !strings.HasSuffix(u.AvatarURL, "no_avatar.png") {
+		j.Avatar = path.Base(u.AvatarURL)
+	}
+	j.CountPosts, _ = u.PostsCount()
+	j.CountBacklog, _ = u.BacklogCount()
+	j.CountComp, _ = u.CompleteCount()
+	return j
+}
+
+// seconds converts a displayed completion time into seconds, 0 if unknown
+func seconds(s string) int {
+	t, err := gohltb.ParseCompletionTime(s)
+	if err != nil {
+		return 0
+	}
+	d, _ := t.Duration()
+	return int(d.Seconds())
+}
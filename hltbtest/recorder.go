@@ -0,0 +1,164 @@
+package hltbtest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// ErrNoRecording is returned by a strict Recorder when a request has no
+// matching recording
+var ErrNoRecording = errors.New("No recording found for request")
+
+// RecorderMode is how a Recorder handles requests
+type RecorderMode int
+
+const (
+	// ModeReplay answers requests from recordings. Requests without a recording
+	// are sent to the network and recorded, unless the Recorder is Strict.
+	ModeReplay RecorderMode = iota
+	// ModeRecord sends every request to the network and records the response,
+	// replacing any existing recording.
+	ModeRecord
+)
+
+// recordingExt is the extension given to every file written by Recorder
+const recordingExt = ".json"
+
+// Recorder is an http.RoundTripper that records exchanges with
+// howlongtobeat.com to a directory and replays them later, so tests can run
+// without network access. It can be used as the Transport of the http.Client
+// in a gohltb.HTTPClient.
+//
+// Requests are matched on their method, path, query (which holds the page) and
+// body (which holds the search form). Each exchange is stored as a JSON file,
+// so recordings can be checked in alongside other test data.
+//
+// example:
+//
+//	rec := hltbtest.NewRecorder("testdata/recordings", hltbtest.ModeReplay)
+//	rec.Strict = true
+//	client := gohltb.NewCustomClient(&gohltb.HTTPClient{Client: &http.Client{Transport: rec}})
+type Recorder struct {
+	Dir       string            // Directory holding the recordings
+	Mode      RecorderMode      // Whether to replay or record requests
+	Strict    bool              // Fail requests without a recording instead of sending them when replaying
+	Transport http.RoundTripper // Transport used to send requests, http.DefaultTransport when nil
+}
+
+// recording is a single recorded exchange
+type recording struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	Body       string      `json:"body,omitempty"`
+	StatusCode int         `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Response   string      `json:"response"`
+}
+
+// NewRecorder creates a Recorder using the recordings in dir
+func NewRecorder(dir string, mode RecorderMode) *Recorder {
+	return &Recorder{Dir: dir, Mode: mode}
+}
+
+// RoundTrip for http.RoundTripper interface
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	path := r.path(req, body)
+
+	if r.Mode == ModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err == nil {
+			rec := &recording{}
+			if err := json.Unmarshal(data, rec); err != nil {
+				return nil, fmt.Errorf("Invalid recording %v: %w", path, err)
+			}
+			return rec.response(req), nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		if r.Strict {
+			return nil, fmt.Errorf("%w: %v %v", ErrNoRecording, req.Method, req.URL)
+		}
+	}
+
+	return r.record(req, body, path)
+}
+
+// record sends the request to the network and writes the exchange to path
+func (r *Recorder) record(req *http.Request, body []byte, path string) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	out := req.Clone(req.Context())
+	out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	resp, err := transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &recording{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		Body:       string(body),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Response:   string(respBody),
+	}
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(r.Dir, 0755); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return nil, err
+	}
+	return rec.response(req), nil
+}
+
+// path returns the file the exchange for the request is recorded in
+func (r *Recorder) path(req *http.Request, body []byte) string {
+	sum := sha256.New()
+	fmt.Fprintf(sum, "%v\n%v\n%v\n", req.Method, req.URL.Path, req.URL.Query().Encode())
+	sum.Write(body)
+	return filepath.Join(r.Dir, hex.EncodeToString(sum.Sum(nil))+recordingExt)
+}
+
+// response builds the recorded response to req
+func (rec *recording) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.StatusCode, http.StatusText(rec.StatusCode)),
+		StatusCode:    rec.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rec.Header,
+		Body:          ioutil.NopCloser(bytes.NewReader([]byte(rec.Response))),
+		ContentLength: int64(len(rec.Response)),
+		Request:       req,
+	}
+}
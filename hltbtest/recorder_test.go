@@ -0,0 +1,80 @@
+package hltbtest_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/fuzzylimes/gohltb"
+	"github.com/fuzzylimes/gohltb/hltbtest"
+)
+
+func TestRecorderReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hltbtest")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	defer os.RemoveAll(dir)
+
+	srv := hltbtest.NewServer()
+	srv.AddGame(&gohltb.GameResult{ID: "1", Title: "Doom", Main: "12 Hours"})
+	rec := hltbtest.NewRecorder(dir, hltbtest.ModeRecord)
+	rec.Transport = srv.Client().Client.Client.Transport
+	client := gohltb.NewCustomClient(&gohltb.HTTPClient{Client: &http.Client{Transport: rec}})
+
+	if _, err := client.SearchGames("doom"); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	srv.Close()
+
+	// Replay with the server gone
+	rec.Mode = hltbtest.ModeReplay
+	rec.Strict = true
+	res, err := client.SearchGames("doom")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(res.Games) != 1 || res.Games[0].Title != "Doom" {
+		fmt.Printf("Got %+v, expected recorded game", res.Games)
+		t.Fail()
+	}
+
+	// A different form is a different request
+	_, err = client.SearchGames("quake")
+	if !errors.Is(err, hltbtest.ErrNoRecording) {
+		fmt.Printf("Got %v, expected ErrNoRecording", err)
+		t.Fail()
+	}
+}
+
+func TestRecorderRecordsMissing(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hltbtest")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	defer os.RemoveAll(dir)
+
+	srv := hltbtest.NewServer()
+	defer srv.Close()
+	rec := hltbtest.NewRecorder(dir, hltbtest.ModeReplay)
+	rec.Transport = srv.Client().Client.Client.Transport
+	client := gohltb.NewCustomClient(&gohltb.HTTPClient{Client: &http.Client{Transport: rec}})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.SearchGames("doom"); err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
+	}
+	if n := len(srv.Requests()); n != 1 {
+		fmt.Printf("Got %v requests, expected 1 with the second replayed", n)
+		t.Fail()
+	}
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 1 {
+		fmt.Printf("Got %v files, expected 1 recording", len(files))
+		t.Fail()
+	}
+}
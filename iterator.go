@@ -0,0 +1,168 @@
+package gohltb
+
+import "context"
+
+// GameIterator walks over every GameResult returned by a query, fetching
+// subsequent pages as they are needed. Create one using HLTBClient.AllGames.
+//
+// example:
+//
+//	it := client.AllGames(ctx, &HLTBQuery{Query: "Mario"})
+//	it.MaxPages = 5
+//	for it.Next() {
+//	    fmt.Println(it.Game().Title)
+//	}
+//	if err := it.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+type GameIterator struct {
+	MaxPages int // Maximum number of pages to retrieve, 0 for no limit
+	MaxItems int // Maximum number of games to return, 0 for no limit
+
+	ctx     context.Context
+	client  *HLTBClient
+	query   *HLTBQuery
+	page    *GameResultsPage
+	current *GameResult
+	index   int
+	pages   int
+	items   int
+	done    bool
+	err     error
+}
+
+// AllGames returns a GameIterator for the provided query. No requests are made
+// until the first call to Next. The provided query is copied, so it will not be
+// modified as pages are retrieved.
+func (h *HLTBClient) AllGames(ctx context.Context, q *HLTBQuery) *GameIterator {
+	query := *q
+	return &GameIterator{ctx: ctx, client: h, query: &query}
+}
+
+// Next advances the iterator to the next game, retrieving the next page if
+// required. Returns false once there are no more games, a limit has been reached,
+// or an error has occurred. Use Err to tell these cases apart.
+func (it *GameIterator) Next() bool {
+	if it.done || (it.MaxItems > 0 && it.items >= it.MaxItems) {
+		it.done = true
+		return false
+	}
+	for it.page == nil || it.index >= len(it.page.Games) {
+		if (it.page != nil && !it.page.HasNext()) || (it.MaxPages > 0 && it.pages >= it.MaxPages) {
+			it.done = true
+			return false
+		}
+		var page *GameResultsPage
+		var err error
+		if it.page == nil {
+			page, err = gameSearch(it.ctx, it.client, it.query)
+		} else {
+			page, err = it.page.GetNextPageContext(it.ctx)
+		}
+		if err != nil {
+			it.err = err
+			it.done = true
+			return false
+		}
+		it.page = page
+		it.index = 0
+		it.pages++
+	}
+	it.current = it.page.Games[it.index]
+	it.index++
+	it.items++
+	return true
+}
+
+// Game returns the current game. Only valid after a call to Next returns true.
+func (it *GameIterator) Game() *GameResult {
+	return it.current
+}
+
+// Page returns the page that the current game belongs to
+func (it *GameIterator) Page() *GameResultsPage {
+	return it.page
+}
+
+// Err returns the error, if any, that stopped the iterator. Games returned
+// before the error occurred remain valid.
+func (it *GameIterator) Err() error {
+	return it.err
+}
+
+// UserIterator walks over every UserResult returned by a query, fetching
+// subsequent pages as they are needed. Create one using HLTBClient.AllUsers.
+type UserIterator struct {
+	MaxPages int // Maximum number of pages to retrieve, 0 for no limit
+	MaxItems int // Maximum number of users to return, 0 for no limit
+
+	ctx     context.Context
+	client  *HLTBClient
+	query   *HLTBQuery
+	page    *UserResultsPage
+	current *UserResult
+	index   int
+	pages   int
+	items   int
+	done    bool
+	err     error
+}
+
+// AllUsers returns a UserIterator for the provided query. No requests are made
+// until the first call to Next. The provided query is copied, so it will not be
+// modified as pages are retrieved.
+func (h *HLTBClient) AllUsers(ctx context.Context, q *HLTBQuery) *UserIterator {
+	query := *q
+	return &UserIterator{ctx: ctx, client: h, query: &query}
+}
+
+// Next advances the iterator to the next user, retrieving the next page if
+// required. Returns false once there are no more users, a limit has been reached,
+// or an error has occurred. Use Err to tell these cases apart.
+func (it *UserIterator) Next() bool {
+	if it.done || (it.MaxItems > 0 && it.items >= it.MaxItems) {
+		it.done = true
+		return false
+	}
+	for it.page == nil || it.index >= len(it.page.Users) {
+		if (it.page != nil && !it.page.HasNext()) || (it.MaxPages > 0 && it.pages >= it.MaxPages) {
+			it.done = true
+			return false
+		}
+		var page *UserResultsPage
+		var err error
+		if it.page == nil {
+			page, err = userSearch(it.ctx, it.client, it.query)
+		} else {
+			page, err = it.page.GetNextPageContext(it.ctx)
+		}
+		if err != nil {
+			it.err = err
+			it.done = true
+			return false
+		}
+		it.page = page
+		it.index = 0
+		it.pages++
+	}
+	it.current = it.page.Users[it.index]
+	it.index++
+	it.items++
+	return true
+}
+
+// User returns the current user. Only valid after a call to Next returns true.
+func (it *UserIterator) User() *UserResult {
+	return it.current
+}
+
+// Page returns the page that the current user belongs to
+func (it *UserIterator) Page() *UserResultsPage {
+	return it.page
+}
+
+// Err returns the error, if any, that stopped the iterator. Users returned
+// before the error occurred remain valid.
+func (it *UserIterator) Err() error {
+	return it.err
+}
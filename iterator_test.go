@@ -0,0 +1,96 @@
+package gohltb
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// makePagedServer serves the first file for page 1 and the second file for page 2.
+// All other pages respond with a 500.
+func makePagedServer(t *testing.T, file1, file2 string) *httptest.Server {
+	data, err := ioutil.ReadFile(file1)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	data2, err := ioutil.ReadFile(file2)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprintln(w, string(data))
+		case "2":
+			fmt.Fprintln(w, string(data2))
+		default:
+			w.WriteHeader(500)
+		}
+	}))
+}
+
+func TestGameIterator(t *testing.T) {
+	ts := makePagedServer(t, "testdata/games/multipage.html", "testdata/games/multipage2.html")
+	defer ts.Close()
+	client := NewCustomClient(&HTTPClient{baseURL: ts.URL})
+
+	it := client.AllGames(context.Background(), &HLTBQuery{})
+	it.MaxPages = 2
+	var count int
+	for it.Next() {
+		count++
+	}
+	if it.Err() != nil {
+		t.Fatal("Unexpected error: ", it.Err())
+	}
+	if count != 2 {
+		fmt.Printf("Got %v, expected 2", count)
+		t.Fail()
+	}
+	if it.Page().CurrentPage != 2 {
+		fmt.Printf("Got %v, expected 2", it.Page().CurrentPage)
+		t.Fail()
+	}
+
+	// without a page limit the iterator should stop on the failed third page
+	it = client.AllGames(context.Background(), &HLTBQuery{})
+	var titles []string
+	for it.Next() {
+		titles = append(titles, it.Game().Title)
+	}
+	if it.Err() == nil {
+		t.Fatal("Expected error from third page")
+	}
+	if len(titles) != 2 {
+		fmt.Printf("Got %v, expected 2", len(titles))
+		t.Fail()
+	}
+}
+
+func TestUserIteratorMaxItems(t *testing.T) {
+	ts := makePagedServer(t, "testdata/users/multipage.html", "testdata/users/multipage2.html")
+	defer ts.Close()
+	client := NewCustomClient(&HTTPClient{baseURL: ts.URL})
+
+	q := &HLTBQuery{}
+	it := client.AllUsers(context.Background(), q)
+	it.MaxItems = 1
+	var count int
+	for it.Next() {
+		count++
+	}
+	if it.Err() != nil {
+		t.Fatal("Unexpected error: ", it.Err())
+	}
+	if count != 1 {
+		fmt.Printf("Got %v, expected 1", count)
+		t.Fail()
+	}
+	if q.Page != 0 {
+		fmt.Printf("Got %v, expected query to be unmodified", q.Page)
+		t.Fail()
+	}
+}
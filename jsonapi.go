@@ -0,0 +1,293 @@
+package gohltb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// jsonPageSize is the number of results requested per page, matching the
+// number of results on a page of the HTML search.
+const jsonPageSize = 20
+
+// jsonSearchRequest is the body posted to the JSON search endpoint
+type jsonSearchRequest struct {
+	SearchType    string            `json:"searchType"`
+	SearchTerms   []string          `json:"searchTerms"`
+	SearchPage    int               `json:"searchPage"`
+	Size          int               `json:"size"`
+	SearchOptions jsonSearchOptions `json:"searchOptions"`
+}
+
+// jsonSearchOptions are the query parameters of a jsonSearchRequest
+type jsonSearchOptions struct {
+	Games      jsonGameOptions `json:"games"`
+	Users      jsonUserOptions `json:"users"`
+	Filter     string          `json:"filter"`
+	Sort       int             `json:"sort"`
+	Randomizer int             `json:"randomizer"`
+}
+
+// jsonGameOptions are the game specific query parameters of a jsonSearchRequest
+type jsonGameOptions struct {
+	UserID        int           `json:"userId"`
+	Platform      string        `json:"platform"`
+	SortCategory  string        `json:"sortCategory"`
+	RangeCategory string        `json:"rangeCategory"`
+	RangeTime     jsonRangeTime `json:"rangeTime"`
+	Modifier      string        `json:"modifier"`
+}
+
+// jsonRangeTime is the completion time filter of a jsonSearchRequest, in hours
+type jsonRangeTime struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// jsonUserOptions are the user specific query parameters of a jsonSearchRequest
+type jsonUserOptions struct {
+	SortCategory string `json:"sortCategory"`
+}
+
+// jsonSearchResponse is the response from the JSON search endpoint. Data is
+// decoded once the type of search is known.
+type jsonSearchResponse struct {
+	Count       int             `json:"count"`
+	PageCurrent int             `json:"pageCurrent"`
+	PageTotal   int             `json:"pageTotal"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// jsonGame is a single game from the JSON search endpoint. Times are in seconds.
+type jsonGame struct {
+	GameID        int    `json:"game_id"`
+	GameName      string `json:"game_name"`
+	GameImage     string `json:"game_image"`
+	CompMain      int    `json:"comp_main"`
+	CompPlus      int    `json:"comp_plus"`
+	Comp100       int    `json:"comp_100"`
+	CompLvlSp     int    `json:"comp_lvl_sp"`
+	CompLvlCo     int    `json:"comp_lvl_co"`
+	CompLvlMp     int    `json:"comp_lvl_mp"`
+	InvestedCo    int    `json:"invested_co"`
+	InvestedMp    int    `json:"invested_mp"`
+	CountComp     int    `json:"count_comp"`
+	CountBacklog  int    `json:"count_backlog"`
+	CountPlaying  int    `json:"count_playing"`
+	CountRetired  int    `json:"count_retired"`
+	CountSpeedrun int    `json:"count_speedrun"`
+	CountReview   int    `json:"count_review"`
+	ReviewScore   int    `json:"review_score"`
+}
+
+// jsonUser is a single user from the JSON search endpoint
+type jsonUser struct {
+	UserID       int    `json:"user_id"`
+	UserName     string `json:"user_name"`
+	Avatar       string `json:"avatar"`
+	Location     string `json:"location"`
+	Gender       string `json:"gender"`
+	Age          int    `json:"age"`
+	CountPosts   int    `json:"count_posts"`
+	CountBacklog int    `json:"count_backlog"`
+	CountComp    int    `json:"count_comp"`
+}
+
+// jsonBackend runs searches against the JSON search endpoint at /api/search,
+// converting the results into the same form as the HTML search. Details are
+// still scraped from the HTML pages.
+type jsonBackend struct {
+	htmlBackend
+}
+
+// SearchGames runs a game search against the JSON endpoint
+func (b jsonBackend) SearchGames(ctx context.Context, q *HLTBQuery) (*GameResultsPage, error) {
+	var games *GameResultsPage
+	err := jsonSearch(ctx, b.h, q, func(resp *jsonSearchResponse) error {
+		var err error
+		games, err = parseJSONGames(resp, q)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return games, nil
+}
+
+// parseJSONGames converts a response from the JSON endpoint into a
+// GameResultsPage
+func parseJSONGames(resp *jsonSearchResponse, q *HLTBQuery) (*GameResultsPage, error) {
+	if resp.Count == 0 {
+		return &GameResultsPage{}, nil
+	}
+	var data []jsonGame
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		return nil, &ParseError{Selector: "data", Page: q.Page, Err: err}
+	}
+
+	games := &GameResultsPage{
+		TotalPages:   resp.PageTotal,
+		TotalMatches: resp.Count,
+		CurrentPage:  q.Page,
+		requestQuery: q,
+	}
+	for _, g := range data {
+		id := strconv.Itoa(g.GameID)
+		game := &GameResult{
+			ID:        id,
+			Title:     sanitizeTitle(g.GameName),
+			URL:       urlPrefix + "game?id=" + id,
+			BoxArtURL: urlPrefix + "games/" + g.GameImage,
+		}
+		// Multiplayer games only have co-op and versus times
+		if g.CompLvlSp == 0 && (g.CompLvlCo == 1 || g.CompLvlMp == 1) {
+			game.Other = map[string]string{
+				"Co-Op": formatSeconds(g.InvestedCo),
+				"Vs.":   formatSeconds(g.InvestedMp),
+			}
+		} else {
+			game.Main = formatSeconds(g.CompMain)
+			game.MainExtra = formatSeconds(g.CompPlus)
+			game.Completionist = formatSeconds(g.Comp100)
+		}
+		if q.Modifier == ShowUserStats {
+			rating := fmt.Sprintf("%v%%", g.ReviewScore)
+			if g.CountReview > 0 {
+				rating = fmt.Sprintf("%v%% by %v", g.ReviewScore, g.CountReview)
+			}
+			game.UserStats = &UserStats{
+				Completed: strconv.Itoa(g.CountComp),
+				Rating:    rating,
+				Backlog:   strconv.Itoa(g.CountBacklog),
+				Playing:   strconv.Itoa(g.CountPlaying),
+				Retired:   strconv.Itoa(g.CountRetired),
+				SpeedRuns: strconv.Itoa(g.CountSpeedrun),
+			}
+		}
+		games.Games = append(games.Games, game)
+	}
+	if games.TotalPages > games.CurrentPage {
+		games.NextPage = games.CurrentPage + 1
+	}
+	return games, nil
+}
+
+// SearchUsers runs a user search against the JSON endpoint
+func (b jsonBackend) SearchUsers(ctx context.Context, q *HLTBQuery) (*UserResultsPage, error) {
+	var users *UserResultsPage
+	err := jsonSearch(ctx, b.h, q, func(resp *jsonSearchResponse) error {
+		var err error
+		users, err = parseJSONUsers(resp, q)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// parseJSONUsers converts a response from the JSON endpoint into a
+// UserResultsPage
+func parseJSONUsers(resp *jsonSearchResponse, q *HLTBQuery) (*UserResultsPage, error) {
+	if resp.Count == 0 {
+		return &UserResultsPage{}, nil
+	}
+	var data []jsonUser
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		return nil, &ParseError{Selector: "data", Page: q.Page, Err: err}
+	}
+
+	users := &UserResultsPage{
+		TotalPages:   resp.PageTotal,
+		TotalMatches: resp.Count,
+		CurrentPage:  q.Page,
+		requestQuery: q,
+	}
+	for _, u := range data {
+		avatar := "avatars/no_avatar.png"
+		if u.Avatar != "" {
+			avatar = "avatars/" + u.Avatar
+		}
+		users.Users = append(users.Users, &UserResult{
+			ID:        u.UserName,
+			Name:      u.UserName,
+			URL:       urlPrefix + "user?n=" + url.QueryEscape(u.UserName),
+			AvatarURL: urlPrefix + avatar,
+			Location:  u.Location,
+			Backlog:   strconv.Itoa(u.CountBacklog),
+			Complete:  strconv.Itoa(u.CountComp),
+			Gender:    u.Gender,
+			Posts:     strconv.Itoa(u.CountPosts),
+			Age:       u.Age,
+		})
+	}
+	if users.TotalPages > users.CurrentPage {
+		users.NextPage = users.CurrentPage + 1
+	}
+	return users, nil
+}
+
+// jsonSearch posts the query to the JSON search endpoint and passes the
+// decoded response to parse
+func jsonSearch(ctx context.Context, h *HLTBClient, q *HLTBQuery, parse func(resp *jsonSearchResponse) error) error {
+	reqBody, err := json.Marshal(buildJSONSearch(q))
+	if err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("%v/api/search", h.Client.baseURL)
+	return fetch(ctx, h, "POST", endpoint, "application/json", string(reqBody), func(body []byte) error {
+		resp := &jsonSearchResponse{}
+		if err := json.Unmarshal(body, resp); err != nil {
+			return &ParseError{Selector: "response", Page: q.Page, Err: err}
+		}
+		return parse(resp)
+	})
+}
+
+// buildJSONSearch will construct the body sent to the JSON search endpoint,
+// the equivalent of buildForm for the HTML search.
+func buildJSONSearch(q *HLTBQuery) *jsonSearchRequest {
+	req := &jsonSearchRequest{
+		SearchType:  string(q.QueryType),
+		SearchTerms: strings.Fields(q.Query),
+		SearchPage:  q.Page,
+		Size:        jsonPageSize,
+	}
+	if req.SearchTerms == nil {
+		req.SearchTerms = []string{}
+	}
+	opts := &req.SearchOptions
+	if q.QueryType == UserQuery {
+		opts.Users.SortCategory = string(q.SortBy)
+	} else {
+		opts.Games.Platform = string(q.Platform)
+		opts.Games.SortCategory = string(q.SortBy)
+		opts.Games.RangeCategory = string(q.LengthType)
+		opts.Games.RangeTime.Min, _ = strconv.Atoi(q.LengthMin)
+		opts.Games.RangeTime.Max, _ = strconv.Atoi(q.LengthMax)
+		opts.Games.Modifier = string(q.Modifier)
+	}
+	if q.SortDirection == ReverseOrder {
+		opts.Sort = 1
+	}
+	if q.Random {
+		opts.Randomizer = 1
+	}
+	return req
+}
+
+// formatSeconds converts a time in seconds into the format displayed by the
+// HTML search: minutes below an hour, otherwise hours to the nearest half.
+func formatSeconds(secs int) string {
+	if secs <= 0 {
+		return CompletionTime{}.String()
+	}
+	if secs < 3600 {
+		return CompletionTime{Value: math.Round(float64(secs) / 60), Unit: TimeMinutes}.String()
+	}
+	return CompletionTime{Value: math.Round(float64(secs)/1800) / 2, Unit: TimeHours}.String()
+}
@@ -0,0 +1,131 @@
+package gohltb
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func makeJSONServer(t *testing.T, file string, req *jsonSearchRequest) *httptest.Server {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/search" || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(404)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(400)
+			return
+		}
+		fmt.Fprintln(w, string(data))
+	}))
+}
+
+func TestJSONGameSearch(t *testing.T) {
+	var req jsonSearchRequest
+	ts := makeJSONServer(t, "testdata/games/search.json", &req)
+	defer ts.Close()
+	client := NewCustomClient(&HTTPClient{baseURL: ts.URL})
+	client.SearchAPI = JSONSearchAPI
+
+	res, err := client.SearchGamesByQuery(&HLTBQuery{Query: "doom eternal", Modifier: ShowUserStats, SortDirection: ReverseOrder})
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if req.SearchType != "games" || len(req.SearchTerms) != 2 || req.SearchPage != 1 ||
+		req.SearchOptions.Games.Modifier != string(ShowUserStats) || req.SearchOptions.Sort != 1 {
+		fmt.Printf("Got %+v, unexpected request", req)
+		t.Fail()
+	}
+	if res.TotalMatches != 42848 || res.TotalPages != 2143 || res.NextPage != 2 || len(res.Games) != 3 {
+		fmt.Printf("Got %v/%v/%v, unexpected page data", res.TotalMatches, res.TotalPages, len(res.Games))
+		t.Fail()
+	}
+	doom := res.Games[0]
+	if doom.ID != "57506" || doom.Main != "13½ Hours" || doom.MainExtra != "18 Hours" || doom.Completionist != "24 Hours" {
+		fmt.Printf("Got %+v, unexpected game", doom)
+		t.Fail()
+	}
+	if doom.UserStats == nil || doom.UserStats.Rating != "87% by 590" || doom.UserStats.Completed != "1600" {
+		fmt.Printf("Got %+v, unexpected user stats", doom.UserStats)
+		t.Fail()
+	}
+	idarb := res.Games[1]
+	if idarb.Main != "" || idarb.Other["Vs."] != "2½ Hours" || idarb.Other["Co-Op"] != "--" {
+		fmt.Printf("Got %+v, expected multiplayer times", idarb)
+		t.Fail()
+	}
+	if res.Games[2].Main != "34 Mins" || res.Games[2].MainExtra != "--" {
+		fmt.Printf("Got %+v, unexpected times", res.Games[2])
+		t.Fail()
+	}
+}
+
+func TestJSONUserSearch(t *testing.T) {
+	var req jsonSearchRequest
+	ts := makeJSONServer(t, "testdata/users/search.json", &req)
+	defer ts.Close()
+	client := NewCustomClient(&HTTPClient{baseURL: ts.URL})
+	client.SearchAPI = JSONSearchAPI
+
+	res, err := client.SearchUsersByQuery(&HLTBQuery{Query: "tiamat", Page: 2})
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if req.SearchType != "users" || req.SearchPage != 2 {
+		fmt.Printf("Got %+v, unexpected request", req)
+		t.Fail()
+	}
+	if res.TotalMatches != 255629 || res.CurrentPage != 2 || res.NextPage != 3 || len(res.Users) != 2 {
+		fmt.Printf("Got %v/%v/%v, unexpected page data", res.TotalMatches, res.CurrentPage, len(res.Users))
+		t.Fail()
+	}
+	user := res.Users[0]
+	if user.ID != "tiamat911" || user.Location != "Quebec, Canada" || user.Complete != "169" || user.Age != 39 {
+		fmt.Printf("Got %+v, unexpected user", user)
+		t.Fail()
+	}
+	if res.Users[1].AvatarURL != urlPrefix+"avatars/no_avatar.png" {
+		fmt.Printf("Got %v, expected default avatar", res.Users[1].AvatarURL)
+		t.Fail()
+	}
+}
+
+func TestJSONEmptySearch(t *testing.T) {
+	var req jsonSearchRequest
+	ts := makeJSONServer(t, "testdata/games/search_empty.json", &req)
+	defer ts.Close()
+	client := NewCustomClient(&HTTPClient{baseURL: ts.URL})
+	client.SearchAPI = JSONSearchAPI
+
+	res, err := client.SearchGames("nothing")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(res.Games) != 0 || res.HasNext() {
+		fmt.Printf("Got %v games, expected 0 and no next page", len(res.Games))
+		t.Fail()
+	}
+}
+
+func TestJSONUserURLEscaped(t *testing.T) {
+	resp := &jsonSearchResponse{
+		Count:     1,
+		PageTotal: 1,
+		Data:      []byte(`[{"user_name": "Tia Mat/#1?"}]`),
+	}
+	res, err := parseJSONUsers(resp, &HLTBQuery{QueryType: UserQuery, Page: 1})
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if url := res.Users[0].URL; url != urlPrefix+"user?n=Tia+Mat%2F%231%3F" {
+		fmt.Printf("Got %v, expected escaped user name", url)
+		t.Fail()
+	}
+}
@@ -0,0 +1,45 @@
+package gohltb
+
+import "github.com/PuerkitoBio/goquery"
+
+// validateLayout checks that a page of search results has the elements that
+// are present on every page of results. It is only run on pages that have
+// results, before they are parsed. Returns a *LayoutError listing every
+// selector that failed.
+func validateLayout(doc *goquery.Document, p int, sel *Selectors) error {
+	var failed []string
+	if doc.Find(sel.Result).Length() == 0 {
+		failed = append(failed, sel.Result)
+	}
+	// Only the first page has the number of matches. Later pages don't always
+	// have page links either, i.e. the last page of some searches, so there is
+	// nothing else to check on them.
+	if p == 1 {
+		header := doc.Find(sel.Header).First()
+		matches, ok := parseMatchCount(header.Text(), sel)
+		if !ok {
+			failed = append(failed, sel.Header+" ("+matchCountLabel(sel)+")")
+		}
+		// More matches than results means there are more pages to link to
+		if ok && matches > doc.Find(sel.Result).Length() && doc.Find(sel.Page).Length() == 0 {
+			failed = append(failed, sel.Page)
+		}
+	}
+
+	if failed == nil {
+		return nil
+	}
+	return &LayoutError{Selectors: failed, Page: p}
+}
+
+// validateGameTimes checks that the games on a page of search results have
+// times, either the standard ones or the short multiplayer ones. It is run once
+// the games have been parsed, so a game that can't be parsed is reported as a
+// ParseError instead. Users aren't required to fill in any details, so there is
+// no equivalent check for them.
+func validateGameTimes(doc *goquery.Document, p int, sel *Selectors) error {
+	if doc.Find(sel.Result).Find(sel.Tidbit+", "+sel.ShortLabel).Length() == 0 {
+		return &LayoutError{Selectors: []string{sel.Tidbit}, Page: p}
+	}
+	return nil
+}
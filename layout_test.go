@@ -0,0 +1,134 @@
+package gohltb
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLayoutChanged(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `<h3>Results</h3><ul><li class="game_result"><a href="game?id=1">Doom</a></li></ul>`)
+	}))
+	defer ts.Close()
+	client := NewClient(WithBaseURL(ts.URL), WithHTMLDump())
+
+	_, err := client.SearchGames("doom")
+	if !errors.Is(err, ErrLayoutChanged) {
+		t.Fatalf("Got %v, expected ErrLayoutChanged", err)
+	}
+	var layoutErr *LayoutError
+	if !errors.As(err, &layoutErr) {
+		t.Fatalf("Got %v, expected LayoutError", err)
+	}
+	if len(layoutErr.Selectors) != 2 || layoutErr.Selectors[0] != "ul > li.back_darkish" || layoutErr.Selectors[1] != "h3 (We Found N)" {
+		fmt.Printf("Got %v, unexpected selectors", layoutErr.Selectors)
+		t.Fail()
+	}
+	if !strings.Contains(layoutErr.HTML, "game_result") {
+		fmt.Printf("Got %v, expected HTML dump", layoutErr.HTML)
+		t.Fail()
+	}
+}
+
+func TestLayoutLastPage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `<h3> Page 2</h3><ul><li class="back_darkish"><h3><a href="user?n=bob">bob</a></h3></li></ul>`)
+	}))
+	defer ts.Close()
+	client := NewClient(WithBaseURL(ts.URL))
+
+	res, err := client.SearchUsersByQuery(&HLTBQuery{Query: "bob", Page: 2})
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(res.Users) != 1 || res.Users[0].ID != "bob" {
+		fmt.Printf("Got %+v, expected bob", res.Users)
+		t.Fail()
+	}
+}
+
+func TestLayoutMissingTimes(t *testing.T) {
+	page := `<h3>We Found 1 Games</h3><ul><li class="back_darkish"><h3><a href="game?id=1">Doom</a></h3><p>12 Hours</p></li></ul>`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, page)
+	}))
+	defer ts.Close()
+	client := NewClient(WithBaseURL(ts.URL), WithHTMLDump())
+
+	_, err := client.SearchGames("doom")
+	var layoutErr *LayoutError
+	if !errors.As(err, &layoutErr) {
+		t.Fatalf("Got %v, expected LayoutError", err)
+	}
+	if len(layoutErr.Selectors) != 1 || layoutErr.Selectors[0] != ".search_list_tidbit" {
+		fmt.Printf("Got %v, expected missing times", layoutErr.Selectors)
+		t.Fail()
+	}
+	if layoutErr.HTML != page {
+		fmt.Printf("Got %v, expected the page as it was sent", layoutErr.HTML)
+		t.Fail()
+	}
+}
+
+func TestLayoutMatchCount(t *testing.T) {
+	// The count must follow "We Found", not just any "Found"
+	page := `<h3>Found 1 Games</h3><ul><li class="back_darkish"><h3><a href="game?id=1">Doom</a></h3><div class="search_list_tidbit">12 Hours</div></li></ul>`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, page)
+	}))
+	defer ts.Close()
+	client := NewClient(WithBaseURL(ts.URL))
+
+	_, err := client.SearchGames("doom")
+	var layoutErr *LayoutError
+	if !errors.As(err, &layoutErr) {
+		t.Fatalf("Got %v, expected LayoutError", err)
+	}
+	if len(layoutErr.Selectors) != 1 || layoutErr.Selectors[0] != "h3 (We Found N)" {
+		fmt.Printf("Got %v, expected missing match count", layoutErr.Selectors)
+		t.Fail()
+	}
+}
+
+func TestLayoutMaintenancePage(t *testing.T) {
+	page := "<html><body><p>Down for maintenance</p></body></html>"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, page)
+	}))
+	defer ts.Close()
+	client := NewClient(WithBaseURL(ts.URL), WithHTMLDump())
+
+	_, err := client.SearchUsers("tiamat")
+	var layoutErr *LayoutError
+	if !errors.As(err, &layoutErr) {
+		t.Fatalf("Got %v, expected LayoutError", err)
+	}
+	if layoutErr.HTML != page {
+		fmt.Printf("Got %v, expected the page as it was sent", layoutErr.HTML)
+		t.Fail()
+	}
+}
+
+func TestLayoutMissingPages(t *testing.T) {
+	// More matches than results, but no links to the other pages
+	page := `<h3>We Found 3 Games</h3><ul><li class="back_darkish"><h3><a href="game?id=1">Doom</a></h3><div class="search_list_tidbit">12 Hours</div></li></ul>`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, page)
+	}))
+	defer ts.Close()
+	client := NewClient(WithBaseURL(ts.URL))
+
+	_, err := client.SearchGames("doom")
+	var layoutErr *LayoutError
+	if !errors.As(err, &layoutErr) {
+		t.Fatalf("Got %v, expected LayoutError", err)
+	}
+	if len(layoutErr.Selectors) != 1 || layoutErr.Selectors[0] != "span.search_list_page" {
+		fmt.Printf("Got %v, expected missing page links", layoutErr.Selectors)
+		t.Fail()
+	}
+}
@@ -0,0 +1,140 @@
+package gohltb
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures a HLTBClient created by NewClient
+type Option func(*HLTBClient)
+
+// NewClient will create a new HLTBClient configured by the provided options.
+// Without any options the client is the same as one from NewDefaultClient.
+// Each client gets its own http.Client, with a 10 second timeout.
+//
+// example:
+//
+//	client := gohltb.NewClient(
+//		gohltb.WithTimeout(5*time.Second),
+//		gohltb.WithUserAgent("my-app/1.0"),
+//		gohltb.WithRateLimit(1, 1),
+//	)
+func NewClient(opts ...Option) *HLTBClient {
+	h := &HLTBClient{
+		Client: &HTTPClient{
+			Client:  &http.Client{Timeout: defaultTimeout},
+			baseURL: queryURL,
+		},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// WithBaseURL sends requests to baseURL instead of howlongtobeat.com, i.e. a
+// mirror or a local stand-in such as the hltbtest server
+func WithBaseURL(baseURL string) Option {
+	return func(h *HLTBClient) {
+		h.Client.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithHTTPClient makes requests using the provided http.Client. A nil client
+// uses a new http.Client with the default timeout.
+func WithHTTPClient(c *http.Client) Option {
+	return func(h *HLTBClient) {
+		if c == nil {
+			c = &http.Client{Timeout: defaultTimeout}
+		}
+		h.Client.Client = c
+	}
+}
+
+// WithTimeout sets the timeout for each request. The client's http.Client is
+// copied rather than modified, so a client from WithHTTPClient is left as is.
+func WithTimeout(d time.Duration) Option {
+	return func(h *HLTBClient) {
+		var c http.Client
+		if h.Client.Client != nil {
+			c = *h.Client.Client
+		}
+		c.Timeout = d
+		h.Client.Client = &c
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with each request
+func WithUserAgent(userAgent string) Option {
+	return func(h *HLTBClient) {
+		h.Client.userAgent = userAgent
+	}
+}
+
+// WithHeaders adds headers to each request. Headers from multiple uses of
+// WithHeaders are combined.
+func WithHeaders(header http.Header) Option {
+	return func(h *HLTBClient) {
+		if h.Client.header == nil {
+			h.Client.header = make(http.Header)
+		}
+		for name, values := range header {
+			for _, v := range values {
+				h.Client.header.Add(name, v)
+			}
+		}
+	}
+}
+
+// WithRetry retries failed requests using the provided RetryPolicy
+func WithRetry(policy *RetryPolicy) Option {
+	return func(h *HLTBClient) {
+		h.Client.Retry = policy
+	}
+}
+
+// WithRateLimit limits the client to rps requests per second, allowing bursts
+// of up to burst requests. See NewRateLimiter. If rps is 0 or less, every
+// request made by the client fails with NewRateLimiter's error instead.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(h *HLTBClient) {
+		limiter, err := NewRateLimiter(rps, burst)
+		if err != nil {
+			limiter = &RateLimiter{err: err}
+		}
+		h.Limiter = limiter
+	}
+}
+
+// WithCache serves repeated requests from the provided Cache
+func WithCache(c Cache) Option {
+	return func(h *HLTBClient) {
+		h.Cache = c
+	}
+}
+
+// WithHTMLDump includes the HTML of the page in any LayoutError returned by
+// the client, for diagnosing changes to the site's layout
+func WithHTMLDump() Option {
+	return func(h *HLTBClient) {
+		h.DumpHTML = true
+	}
+}
+
+// WithSelectors scrapes howlongtobeat.com using the provided Selectors
+func WithSelectors(s *Selectors) Option {
+	return func(h *HLTBClient) {
+		h.Selectors = s
+	}
+}
+
+// WithUnknownLabelHandler calls fn for each detail in search results with a
+// label the library doesn't recognise, i.e. to alert on changes to the site.
+// Searches run concurrently will call fn concurrently, so it must be safe for
+// concurrent use.
+func WithUnknownLabelHandler(fn func(queryType QueryType, label, value string)) Option {
+	return func(h *HLTBClient) {
+		h.OnUnknownLabel = fn
+	}
+}
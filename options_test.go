@@ -0,0 +1,61 @@
+package gohltb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewClientOptions(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/games/basic_response.html")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	var header http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		fmt.Fprintln(w, string(data))
+	}))
+	defer ts.Close()
+
+	cache := NewMemoryCache(10, time.Minute)
+	client := NewClient(
+		WithBaseURL(ts.URL+"/"),
+		WithUserAgent("gohltb-test"),
+		WithHeaders(http.Header{"X-Test": {"1"}}),
+		WithRateLimit(100, 1),
+		WithCache(cache),
+	)
+	if _, err := client.SearchGames("pokemon red blue"); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if header.Get("User-Agent") != "gohltb-test" || header.Get("X-Test") != "1" {
+		fmt.Printf("Got %v, expected configured headers", header)
+		t.Fail()
+	}
+	if client.Limiter == nil || cache.Stats().Entries != 1 {
+		fmt.Printf("Got %+v, expected limiter and cached response", cache.Stats())
+		t.Fail()
+	}
+}
+
+func TestNewClientTimeout(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Minute}
+	client := NewClient(WithHTTPClient(httpClient), WithTimeout(time.Second))
+	if client.Client.Client.Timeout != time.Second || httpClient.Timeout != time.Minute {
+		fmt.Printf("Got %v, expected a copy with the new timeout", client.Client.Client.Timeout)
+		t.Fail()
+	}
+	client = NewClient(WithHTTPClient(nil), WithTimeout(time.Second))
+	if client.Client.Client == nil || client.Client.Client.Timeout != time.Second {
+		fmt.Println("Expected a new http.Client with the timeout for a nil client")
+		t.Fail()
+	}
+	if NewDefaultClient().Client.Client == NewDefaultClient().Client.Client {
+		fmt.Println("Expected each client to have its own http.Client")
+		t.Fail()
+	}
+}
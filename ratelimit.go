@@ -0,0 +1,98 @@
+package gohltb
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket used to limit the rate of requests sent to
+// howlongtobeat.com. A single RateLimiter is safe to share between goroutines,
+// and will limit every request made through the HLTBClient it's attached to.
+//
+// example:
+//
+//	limiter, err := gohltb.NewRateLimiter(2, 5)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	client := gohltb.NewDefaultClient()
+//	client.Limiter = limiter
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64 // maximum number of tokens held
+	tokens float64 // tokens currently held, negative when requests are queued
+	last   time.Time
+	err    error // returned by every Wait, for a limiter that was misconfigured
+}
+
+// NewRateLimiter creates a RateLimiter that allows rps requests per second on
+// average, with bursts of up to burst requests. A burst less than 1 is treated
+// as 1. An rps of 0 or less is an error rather than no limit, so a mistyped
+// rate can't turn off limiting; leave HLTBClient.Limiter nil for no limit.
+func NewRateLimiter(rps float64, burst int) (*RateLimiter, error) {
+	if !(rps > 0) {
+		return nil, invalidRate(rps)
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}, nil
+}
+
+// invalidRate is the error for a rate that wouldn't limit any requests
+func invalidRate(rps float64) error {
+	return fmt.Errorf("invalid rate limit of %v requests per second, must be greater than 0", rps)
+}
+
+// Wait blocks until a request is allowed to be made, or until ctx is done. If
+// ctx is done first, the context's error is returned and no request is counted.
+// A limiter without a valid rate, i.e. the zero value, rejects every request.
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if r.err != nil {
+		return r.err
+	}
+	if !(r.rate > 0) {
+		return invalidRate(r.rate)
+	}
+
+	r.mu.Lock()
+	now := time.Now()
+	r.tokens += now.Sub(r.last).Seconds() * r.rate
+	if r.tokens > r.burst {
+		r.tokens = r.burst
+	}
+	r.last = now
+	r.tokens--
+	var wait time.Duration
+	if r.tokens < 0 {
+		wait = time.Duration(-r.tokens / r.rate * float64(time.Second))
+	}
+	r.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// hand the reserved token back so it can be used by another request
+		r.mu.Lock()
+		r.tokens++
+		r.mu.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,94 @@
+package gohltb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterWait(t *testing.T) {
+	limiter, err := NewRateLimiter(20, 1)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
+	}
+	// first request is free, the next two wait 50ms each
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		fmt.Printf("Got %v, expected at least 100ms", elapsed)
+		t.Fail()
+	}
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+	limiter, err := NewRateLimiter(0.1, 1)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); err != context.DeadlineExceeded {
+		fmt.Printf("Got %v, expected %v", err, context.DeadlineExceeded)
+		t.Fail()
+	}
+}
+
+func TestRateLimitedClient(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintln(w, "<li>No results</li>")
+	}))
+	defer ts.Close()
+
+	client := NewCustomClient(&HTTPClient{baseURL: ts.URL})
+	client.Limiter, _ = NewRateLimiter(0.1, 1)
+
+	if _, err := client.SearchGames("bugsnax"); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.SearchGamesContext(ctx, "bugsnax"); err == nil {
+		t.Fatal("Expected rate limited request to time out")
+	}
+	if requests != 1 {
+		fmt.Printf("Got %v, expected 1", requests)
+		t.Fail()
+	}
+}
+
+func TestInvalidRateLimit(t *testing.T) {
+	for _, rps := range []float64{0, -1} {
+		if _, err := NewRateLimiter(rps, 1); err == nil {
+			fmt.Printf("Got no error for %v, expected an error", rps)
+			t.Fail()
+		}
+	}
+
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintln(w, "<li>No results</li>")
+	}))
+	defer ts.Close()
+
+	client := NewClient(WithBaseURL(ts.URL), WithRateLimit(0, 1))
+	if _, err := client.SearchGames("bugsnax"); err == nil {
+		t.Fatal("Expected request with an invalid rate limit to fail")
+	}
+	if requests != 0 {
+		fmt.Printf("Got %v, expected 0", requests)
+		t.Fail()
+	}
+}
@@ -0,0 +1,128 @@
+package gohltb
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried. Set one on
+// HTTPClient.Retry to enable retries; when no policy is set each request is
+// only attempted once.
+//
+// Backoff between attempts doubles from BaseBackoff up to MaxBackoff. When the
+// server responds with a 429 or 503 that includes a Retry-After header, the
+// header's value is used instead, still limited to MaxBackoff.
+type RetryPolicy struct {
+	MaxAttempts     int              // Total number of attempts, including the first
+	BaseBackoff     time.Duration    // Wait before the first retry
+	MaxBackoff      time.Duration    // Upper limit for the wait between attempts, 0 for no limit
+	Jitter          float64          // Fraction (0-1) of each wait that is randomized
+	RetryableStatus []int            // HTTP status codes that should be retried
+	RetryableError  func(error) bool // Decides if a request error should be retried, nil retries all
+}
+
+// DefaultRetryPolicy returns a RetryPolicy with reasonable defaults: 3 attempts,
+// backing off from 500ms to 10s, retrying on 429 and 5xx gateway/server errors.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:     3,
+		BaseBackoff:     500 * time.Millisecond,
+		MaxBackoff:      10 * time.Second,
+		Jitter:          0.2,
+		RetryableStatus: []int{429, 500, 502, 503, 504},
+	}
+}
+
+// attempts returns the number of attempts allowed by the policy
+func (r *RetryPolicy) attempts() int {
+	if r == nil || r.MaxAttempts < 1 {
+		return 1
+	}
+	return r.MaxAttempts
+}
+
+// retryable decides whether the error from an attempt should be retried
+func (r *RetryPolicy) retryable(err error) bool {
+	if se, ok := err.(*HTTPStatusError); ok {
+		for _, code := range r.RetryableStatus {
+			if code == se.StatusCode {
+				return true
+			}
+		}
+		return false
+	}
+	if r.RetryableError != nil {
+		return r.RetryableError(err)
+	}
+	return true
+}
+
+// backoff returns how long to wait before the next attempt, where attempt is
+// the number of the attempt that just failed.
+func (r *RetryPolicy) backoff(attempt int, err error) time.Duration {
+	if se, ok := err.(*HTTPStatusError); ok && se.retryAfter > 0 {
+		if r.MaxBackoff > 0 && se.retryAfter > r.MaxBackoff {
+			return r.MaxBackoff
+		}
+		return se.retryAfter
+	}
+	d := r.BaseBackoff
+	for i := 1; i < attempt && (r.MaxBackoff == 0 || d < r.MaxBackoff); i++ {
+		d *= 2
+	}
+	if r.MaxBackoff > 0 && d > r.MaxBackoff {
+		d = r.MaxBackoff
+	}
+	if r.Jitter > 0 {
+		d -= time.Duration(rand.Float64() * r.Jitter * float64(d))
+	}
+	return d
+}
+
+// parseRetryAfter reads the Retry-After header of 429 and 503 responses, which
+// can either be a number of seconds or an HTTP date.
+func parseRetryAfter(resp *http.Response) time.Duration {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+// withRetries calls attempt until it succeeds, the policy's attempts are used
+// up, or the error is not retryable. Waiting between attempts respects ctx.
+func withRetries(ctx context.Context, policy *RetryPolicy, attempt func() error) error {
+	max := policy.attempts()
+	for n := 1; ; n++ {
+		err := attempt()
+		if err == nil {
+			return nil
+		}
+		if n >= max || ctx.Err() != nil || !policy.retryable(err) {
+			if n > 1 {
+				return &RetryError{Attempts: n, Err: err}
+			}
+			return err
+		}
+
+		timer := time.NewTimer(policy.backoff(n, err))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return &RetryError{Attempts: n, Err: ctx.Err()}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package gohltb
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryOnServerError(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(503)
+			return
+		}
+		fmt.Fprintln(w, "<li>No results</li>")
+	}))
+	defer ts.Close()
+
+	client := NewCustomClient(&HTTPClient{
+		baseURL: ts.URL,
+		Retry:   &RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, RetryableStatus: []int{503}},
+	})
+	if _, err := client.SearchGames("bugsnax"); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if requests != 3 {
+		fmt.Printf("Got %v, expected 3", requests)
+		t.Fail()
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(429)
+	}))
+	defer ts.Close()
+
+	client := NewCustomClient(&HTTPClient{
+		baseURL: ts.URL,
+		Retry:   &RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond, RetryableStatus: []int{429}},
+	})
+	_, err := client.SearchUsers("bob")
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("Got %v, expected RetryError", err)
+	}
+	if retryErr.Attempts != 2 || requests != 2 {
+		fmt.Printf("Got %v attempts and %v requests, expected 2", retryErr.Attempts, requests)
+		t.Fail()
+	}
+}
+
+func TestNoRetryOnClientError(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(400)
+	}))
+	defer ts.Close()
+
+	client := NewCustomClient(&HTTPClient{baseURL: ts.URL, Retry: DefaultRetryPolicy()})
+	if _, err := client.SearchGames("bugsnax"); err == nil {
+		t.Fatal("Expected error")
+	}
+	if requests != 1 {
+		fmt.Printf("Got %v, expected 1", requests)
+		t.Fail()
+	}
+}
+
+func TestRetryAfterHeader(t *testing.T) {
+	resp := &http.Response{StatusCode: 429, Header: http.Header{"Retry-After": {"3"}}}
+	if d := parseRetryAfter(resp); d != 3*time.Second {
+		fmt.Printf("Got %v, expected 3s", d)
+		t.Fail()
+	}
+	resp.StatusCode = 500
+	if d := parseRetryAfter(resp); d != 0 {
+		fmt.Printf("Got %v, expected 0", d)
+		t.Fail()
+	}
+
+	policy := &RetryPolicy{BaseBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	if d := policy.backoff(2, &HTTPStatusError{StatusCode: 429, retryAfter: 3 * time.Second}); d != 3*time.Second {
+		fmt.Printf("Got %v, expected 3s", d)
+		t.Fail()
+	}
+	if d := policy.backoff(2, &HTTPStatusError{StatusCode: 503, retryAfter: 24 * time.Hour}); d != 5*time.Second {
+		fmt.Printf("Got %v, expected 5s", d)
+		t.Fail()
+	}
+	if d := policy.backoff(5, errors.New("test")); d != 5*time.Second {
+		fmt.Printf("Got %v, expected 5s", d)
+		t.Fail()
+	}
+}
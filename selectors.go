@@ -0,0 +1,380 @@
+package gohltb
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"regexp/syntax"
+
+	"github.com/andybalholm/cascadia"
+)
+
+// Selectors are the CSS selectors and labels used to scrape howlongtobeat.com.
+// When the site changes its markup, the selectors can be updated on a client,
+// or loaded from a file with LoadSelectors, without waiting for a new release
+// of the library.
+//
+// Start from DefaultSelectors when overriding individual selectors, as empty
+// selectors will not match anything. Fields tagged selectors:"text" hold text
+// to match on the page, rather than CSS selectors.
+type Selectors struct {
+	Result       string     `json:"result"`                       // Each game or user in the results
+	ResultLink   string     `json:"result-link"`                  // Link to the game or user page within a result
+	ResultImage  string     `json:"result-image"`                 // Box art or avatar within a result
+	DetailsBlock string     `json:"details-block"`                // Block holding a result's details
+	Label        string     `json:"label"`                        // Label of a detail, followed by its value
+	ShortLabel   string     `json:"short-label"`                  // Label of a multiplayer game's times, followed by its value
+	Tidbit       string     `json:"tidbit"`                       // Any detail, labels or values, of a game
+	Location     string     `json:"location"`                     // User's location within a result
+	Accolades    string     `json:"accolades"`                    // Header holding a user's name and accolades
+	Header       string     `json:"header"`                       // Header holding the number of matches
+	MatchCount   string     `json:"match-count" selectors:"text"` // Regular expression capturing the number of matches in the header
+	Page         string     `json:"page"`                         // Each link to a page of results
+	Message      string     `json:"message"`                      // Element holding the NoResults text when nothing matches
+	NoResults    string     `json:"no-results" selectors:"text"`  // Text shown when nothing matches the query
+	GameLink     string     `json:"game-link" selectors:"text"`   // Part of a game link before the game's ID
+	UserLink     string     `json:"user-link" selectors:"text"`   // Part of a user link before the user's ID
+	GameLabels   GameLabels `json:"game-labels" selectors:"text"` // Labels of a game's details
+	UserLabels   UserLabels `json:"user-labels" selectors:"text"` // Labels of a user's details
+	UserGames    UserGames  `json:"user-games"`                   // Selectors and column headers of a user's game list
+	GamePage     GamePage   `json:"game-page"`                    // Selectors and labels of a game's page
+	UserPage     UserPage   `json:"user-page"`                    // Selectors and labels of a user's profile
+}
+
+// GameLabels are the labels of the details shown for a game in search results
+type GameLabels struct {
+	Main          string `json:"main"`
+	MainExtra     string `json:"main-extra"`
+	Completionist string `json:"completionist"`
+	Polled        string `json:"polled"`
+	Rated         string `json:"rated"`
+	Backlog       string `json:"backlog"`
+	Playing       string `json:"playing"`
+	SpeedRuns     string `json:"speedruns"`
+	Retired       string `json:"retired"`
+}
+
+// UserLabels are the labels of the details shown for a user in search results
+type UserLabels struct {
+	Backlog  string `json:"backlog"`
+	Complete string `json:"complete"`
+	Gender   string `json:"gender"`
+	Age      string `json:"age"`
+	Posts    string `json:"posts"`
+}
+
+// UserGames are the selectors and column headers used to scrape a page of one
+// of a user's game lists. Columns are matched by their header, so the order of
+// the columns doesn't matter.
+type UserGames struct {
+	Table         string `json:"table"`                          // Table of games in the list
+	Header        string `json:"header"`                         // Each column header of the table
+	Row           string `json:"row"`                            // Each game in the table
+	Cell          string `json:"cell"`                           // Each cell of a game's row
+	TitleLink     string `json:"title-link"`                     // Link to the game page within the title cell
+	Title         string `json:"title" selectors:"text"`         // Header of the title column
+	Platform      string `json:"platform" selectors:"text"`      // Header of the platform column
+	Main          string `json:"main" selectors:"text"`          // Header of the Main Story column
+	MainExtra     string `json:"main-extra" selectors:"text"`    // Header of the Main + Extras column
+	Completionist string `json:"completionist" selectors:"text"` // Header of the Completionist column
+	Completed     string `json:"completed" selectors:"text"`     // Header of the completion date column
+}
+
+// GamePage are the selectors and labels used to scrape a game's page
+type GamePage struct {
+	Title       string         `json:"title"`                   // Header holding the game's title
+	BoxArt      string         `json:"box-art"`                 // Box art image
+	Time        string         `json:"time"`                    // Each completion time at the top of the page
+	TimeLabel   string         `json:"time-label"`              // Label of a completion time
+	TimeValue   string         `json:"time-value"`              // Value of a completion time
+	Info        string         `json:"info"`                    // Each of the game's profile details
+	InfoLabel   string         `json:"info-label"`              // Label of a profile detail, followed by its value
+	Description string         `json:"description"`             // Profile detail holding the game's summary
+	ReadMore    string         `json:"read-more"`               // Link to expand the summary, left out of the description
+	Table       string         `json:"table"`                   // Each table of submitted times
+	TableHeader string         `json:"table-header"`            // Each column header of a table
+	TableRow    string         `json:"table-row"`               // Each row of a table
+	TableCell   string         `json:"table-cell"`              // Each cell of a row
+	Labels      GamePageLabels `json:"labels" selectors:"text"` // Labels of the game's details and times
+}
+
+// GamePageLabels are the labels used on a game's page. The site words some of
+// them differently depending on where they appear or how many values there
+// are, so each label is a list of the wordings it can have.
+type GamePageLabels struct {
+	Main                  []string `json:"main"`                   // Main Story times
+	MainExtra             []string `json:"main-extra"`             // Main + Extras times
+	Completionist         []string `json:"completionist"`          // Completionist times
+	AllStyles             []string `json:"all-styles"`             // Times across all play styles
+	Platforms             []string `json:"platforms"`              // Platforms detail
+	Genres                []string `json:"genres"`                 // Genres detail
+	Developer             []string `json:"developer"`              // Developer detail
+	Publisher             []string `json:"publisher"`              // Publisher detail
+	ReleaseDates          []string `json:"release-dates"`          // Release date details, one for each region
+	Platform              []string `json:"platform"`               // First column of the platform table
+	Polled                []string `json:"polled"`                 // Polled column
+	Average               []string `json:"average"`                // Average column
+	Median                []string `json:"median"`                 // Median column, which marks a table of time stats
+	Rushed                []string `json:"rushed"`                 // Rushed column
+	Leisure               []string `json:"leisure"`                // Leisure column
+	PlatformMain          []string `json:"platform-main"`          // Main Story column of the platform table
+	PlatformMainExtra     []string `json:"platform-main-extra"`    // Main + Extras column of the platform table
+	PlatformCompletionist []string `json:"platform-completionist"` // Completionist column of the platform table
+	Fastest               []string `json:"fastest"`                // Fastest column
+	Slowest               []string `json:"slowest"`                // Slowest column
+}
+
+// UserPage are the selectors and labels used to scrape a user's profile
+type UserPage struct {
+	Name      string         `json:"name"`                    // Header holding the user's name and accolades
+	Accolades string         `json:"accolades"`               // Accolades within the header, left out of the name
+	Avatar    string         `json:"avatar"`                  // Avatar image
+	List      string         `json:"list"`                    // Each of the user's lists
+	ListLabel string         `json:"list-label"`              // Name of a list
+	ListCount string         `json:"list-count"`              // Number of games in a list
+	Info      string         `json:"info"`                    // Each of the user's profile details
+	InfoLabel string         `json:"info-label"`              // Label of a profile detail, followed by its value
+	Bio       string         `json:"bio"`                     // Profile detail holding the user's bio
+	Labels    UserPageLabels `json:"labels" selectors:"text"` // Labels of the user's lists and details
+}
+
+// UserPageLabels are the labels used on a user's profile
+type UserPageLabels struct {
+	Playing   string `json:"playing"`
+	Backlog   string `json:"backlog"`
+	Replays   string `json:"replays"`
+	Custom    string `json:"custom"`
+	Completed string `json:"completed"`
+	Retired   string `json:"retired"`
+	Location  string `json:"location"`
+	Joined    string `json:"joined"`
+	Playtime  string `json:"playtime"`
+}
+
+// defaultSelectors are used by clients that don't set their own Selectors
+var defaultSelectors = DefaultSelectors()
+
+// DefaultSelectors returns the Selectors matching the current layout of
+// howlongtobeat.com
+func DefaultSelectors() *Selectors {
+	return &Selectors{
+		Result:       "ul > li.back_darkish",
+		ResultLink:   "h3 > a",
+		ResultImage:  "div > a > img",
+		DetailsBlock: ".search_list_details_block",
+		Label:        ".search_list_tidbit.text_white",
+		ShortLabel:   ".search_list_tidbit_short",
+		Tidbit:       ".search_list_tidbit",
+		Location:     "h4",
+		Accolades:    ".search_list_details > h3",
+		Header:       "h3",
+		MatchCount:   `We Found ([0-9][0-9,.]*)`,
+		Page:         "span.search_list_page",
+		Message:      "li",
+		NoResults:    "No results",
+		GameLink:     "game?id=",
+		UserLink:     "user?n=",
+		GameLabels: GameLabels{
+			Main:          "Main Story",
+			MainExtra:     "Main + Extra",
+			Completionist: "Completionist",
+			Polled:        "Polled",
+			Rated:         "Rated",
+			Backlog:       "Backlog",
+			Playing:       "Playing",
+			SpeedRuns:     "Speedruns",
+			Retired:       "Retired",
+		},
+		UserLabels: UserLabels{
+			Backlog:  "Backlog",
+			Complete: "Complete",
+			Gender:   "Gender",
+			Age:      "Age",
+			Posts:    "Posts",
+		},
+		UserGames: UserGames{
+			Table:         "table.user_game_list",
+			Header:        "thead td",
+			Row:           "tbody tr",
+			Cell:          "td",
+			TitleLink:     "a",
+			Title:         "Title",
+			Platform:      "Platform",
+			Main:          "Main",
+			MainExtra:     "Main +",
+			Completionist: "100%",
+			Completed:     "Completed",
+		},
+		GamePage: GamePage{
+			Title:       ".profile_header",
+			BoxArt:      ".game_image img",
+			Time:        ".game_times li",
+			TimeLabel:   "h5",
+			TimeValue:   "div",
+			Info:        ".profile_info",
+			InfoLabel:   "strong",
+			Description: ".large",
+			ReadMore:    "span",
+			Table:       "table.game_main_table",
+			TableHeader: "thead td",
+			TableRow:    "tbody tr",
+			TableCell:   "td",
+			Labels: GamePageLabels{
+				Main:                  []string{"Main Story"},
+				MainExtra:             []string{"Main + Extras", "Main + Extra"},
+				Completionist:         []string{"Completionist"},
+				AllStyles:             []string{"All Styles", "All PlayStyles"},
+				Platforms:             []string{"Platform", "Platforms"},
+				Genres:                []string{"Genre", "Genres"},
+				Developer:             []string{"Developer", "Developers"},
+				Publisher:             []string{"Publisher", "Publishers"},
+				ReleaseDates:          []string{"NA", "EU", "JP"},
+				Platform:              []string{"Platform"},
+				Polled:                []string{"Polled"},
+				Average:               []string{"Average"},
+				Median:                []string{"Median"},
+				Rushed:                []string{"Rushed"},
+				Leisure:               []string{"Leisure"},
+				PlatformMain:          []string{"Main"},
+				PlatformMainExtra:     []string{"Main +"},
+				PlatformCompletionist: []string{"100%"},
+				Fastest:               []string{"Fastest"},
+				Slowest:               []string{"Slowest"},
+			},
+		},
+		UserPage: UserPage{
+			Name:      ".profile_header",
+			Accolades: "span",
+			Avatar:    ".profile_avatar img",
+			List:      ".profile_details li",
+			ListLabel: "h4",
+			ListCount: "span",
+			Info:      ".profile_info",
+			InfoLabel: "strong",
+			Bio:       ".large",
+			Labels: UserPageLabels{
+				Playing:   "Playing",
+				Backlog:   "Backlog",
+				Replays:   "Replays",
+				Custom:    "Custom",
+				Completed: "Completed",
+				Retired:   "Retired",
+				Location:  "Location",
+				Joined:    "Joined",
+				Playtime:  "Playtime",
+			},
+		},
+	}
+}
+
+// LoadSelectors reads Selectors from a JSON file. Selectors missing from the
+// file keep their default value, so the file only needs the ones being changed.
+// Returns an error if the file sets a selector to an empty string or invalid
+// CSS, or sets a MatchCount that isn't a regular expression with a single group.
+//
+// example file:
+//
+//	{"result": "ul > li.game_result", "game-labels": {"main": "Main"}}
+func LoadSelectors(file string) (*Selectors, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	s := DefaultSelectors()
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	if err := checkSelectors(reflect.ValueOf(s).Elem(), "", false); err != nil {
+		return nil, fmt.Errorf("invalid selectors in %v: %w", file, err)
+	}
+	if _, err := matchCountRegex(s); err != nil {
+		return nil, fmt.Errorf("invalid selectors in %v: %w", file, err)
+	}
+	return s, nil
+}
+
+// checkSelectors returns an error naming the first empty string or list, or
+// invalid CSS selector, in v, a struct of selectors, by the key it has in a
+// selectors file. Fields tagged selectors:"text", and anything within them,
+// are only checked for being empty.
+func checkSelectors(v reflect.Value, prefix string, text bool) error {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		key := prefix + v.Type().Field(i).Tag.Get("json")
+		isText := text || v.Type().Field(i).Tag.Get("selectors") == "text"
+		switch field.Kind() {
+		case reflect.Struct:
+			if err := checkSelectors(field, key+".", isText); err != nil {
+				return err
+			}
+		case reflect.String:
+			if field.String() == "" {
+				return fmt.Errorf("%v is empty", key)
+			}
+			if isText {
+				continue
+			}
+			if _, err := cascadia.Compile(field.String()); err != nil {
+				return fmt.Errorf("%v: %w", key, err)
+			}
+		case reflect.Slice:
+			if field.Len() == 0 {
+				return fmt.Errorf("%v is empty", key)
+			}
+			for j := 0; j < field.Len(); j++ {
+				if field.Index(j).String() == "" {
+					return fmt.Errorf("%v[%v] is empty", key, j)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// matchCountRegex compiles the MatchCount of s, which must have exactly one
+// group for the number of matches
+func matchCountRegex(s *Selectors) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(s.MatchCount)
+	if err != nil {
+		return nil, fmt.Errorf("match-count: %w", err)
+	}
+	if re.NumSubexp() != 1 {
+		return nil, fmt.Errorf("match-count: expected 1 group, found %v", re.NumSubexp())
+	}
+	return re, nil
+}
+
+// matchCountLabel describes the MatchCount of s in a LayoutError, with the
+// number of matches shown as N, i.e. "We Found N"
+func matchCountLabel(s *Selectors) string {
+	re, err := syntax.Parse(s.MatchCount, syntax.Perl)
+	if err != nil {
+		return s.MatchCount
+	}
+	if re.Op == syntax.OpCapture {
+		return "N"
+	}
+	replaceCaptures(re)
+	return re.String()
+}
+
+// replaceCaptures replaces each capture group within re with a literal N
+func replaceCaptures(re *syntax.Regexp) {
+	for i, sub := range re.Sub {
+		if sub.Op == syntax.OpCapture {
+			re.Sub[i] = &syntax.Regexp{Op: syntax.OpLiteral, Rune: []rune("N")}
+			continue
+		}
+		replaceCaptures(sub)
+	}
+}
+
+// selectors returns the client's Selectors, or the defaults if none are set
+func (h *HLTBClient) selectors() *Selectors {
+	if h.Selectors != nil {
+		return h.Selectors
+	}
+	return defaultSelectors
+}
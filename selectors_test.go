@@ -0,0 +1,216 @@
+package gohltb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadSelectors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gohltb")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "selectors.json")
+	config := `{"result": "ul > li.game_result", "game-labels": {"main": "Main"}}`
+	if err := ioutil.WriteFile(file, []byte(config), 0644); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	sel, err := LoadSelectors(file)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if sel.Result != "ul > li.game_result" || sel.GameLabels.Main != "Main" ||
+		sel.GameLabels.MainExtra != "Main + Extra" || sel.Page != "span.search_list_page" {
+		fmt.Printf("Got %+v, expected overrides merged with defaults", sel)
+		t.Fail()
+	}
+	if _, err := LoadSelectors(filepath.Join(dir, "missing.json")); err == nil {
+		fmt.Println("Expected error for missing file")
+		t.Fail()
+	}
+}
+
+func TestClientSelectors(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/games/basic_response.html")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	// Simulate the site renaming a class and a label
+	page := strings.ReplaceAll(string(data), "back_darkish", "game_result")
+	page = strings.ReplaceAll(page, ">Main Story<", ">Main<")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, page)
+	}))
+	defer ts.Close()
+
+	sel := DefaultSelectors()
+	sel.Result = "ul > li.game_result"
+	sel.GameLabels.Main = "Main"
+	client := NewClient(WithBaseURL(ts.URL), WithSelectors(sel))
+
+	res, err := client.SearchGames("pokemon red blue")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(res.Games) != 2 || res.Games[0].Main == "" {
+		fmt.Printf("Got %+v, expected games using the new selectors", res.Games)
+		t.Fail()
+	}
+
+	if _, err := NewClient(WithBaseURL(ts.URL)).SearchGames("pokemon red blue"); err == nil {
+		fmt.Println("Expected error using the default selectors")
+		t.Fail()
+	}
+}
+
+func TestLoadSelectorsInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gohltb")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "selectors.json")
+
+	for _, config := range []string{
+		`{"no-results": ""}`,
+		`{"user-games": {"table": ""}}`,
+		`{"match-count": "We Found [0-9]+"}`,
+		`{"match-count": "We Found ([0-9]+"}`,
+		`{"game-page": {"labels": {"main": []}}}`,
+		`{"result": "ul >> li"}`,
+		`{"game-page": {"table": "table["}}`,
+	} {
+		if err := ioutil.WriteFile(file, []byte(config), 0644); err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
+		if _, err := LoadSelectors(file); err == nil {
+			fmt.Printf("Expected error loading %v\n", config)
+			t.Fail()
+		}
+	}
+}
+
+func TestUserGamesSelectors(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/users/games.html")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	// Simulate the site renaming the table and a column
+	page := strings.ReplaceAll(string(data), "user_game_list", "game_list")
+	page = strings.ReplaceAll(page, ">Main +<", ">Main + Extras<")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, page)
+	}))
+	defer ts.Close()
+
+	sel := DefaultSelectors()
+	sel.UserGames.Table = "table.game_list"
+	sel.UserGames.MainExtra = "Main + Extras"
+	client := NewClient(WithBaseURL(ts.URL), WithSelectors(sel))
+
+	res, err := client.GetUserGames(context.Background(), "tiamat911", ListCompleted, 1)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(res.Games) != 2 || res.Games[1].MainExtra == 0 {
+		fmt.Printf("Got %+v, expected games using the new selectors", res.Games)
+		t.Fail()
+	}
+}
+
+func TestDetailPageSelectors(t *testing.T) {
+	game, err := ioutil.ReadFile("testdata/games/detail.html")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	profile, err := ioutil.ReadFile("testdata/users/profile.html")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	// Simulate the site renaming classes and labels on both pages
+	gamePage := strings.ReplaceAll(string(game), "game_main_table", "times_table")
+	gamePage = strings.ReplaceAll(gamePage, "<h5>Main Story</h5>", "<h5>Story</h5>")
+	userPage := strings.ReplaceAll(string(profile), "profile_details", "profile_lists")
+	userPage = strings.ReplaceAll(userPage, "<h4>Backlog</h4>", "<h4>Pile of Shame</h4>")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/game" {
+			fmt.Fprintln(w, gamePage)
+			return
+		}
+		fmt.Fprintln(w, userPage)
+	}))
+	defer ts.Close()
+
+	sel := DefaultSelectors()
+	sel.GamePage.Table = "table.times_table"
+	sel.GamePage.Labels.Main = []string{"Story", "Main Story"}
+	sel.UserPage.List = ".profile_lists li"
+	sel.UserPage.Labels.Backlog = "Pile of Shame"
+	client := NewClient(WithBaseURL(ts.URL), WithSelectors(sel))
+
+	res, err := client.GetGame(context.Background(), "57506")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if res.Main == "" || res.TimeStats == nil || res.TimeStats.Main == nil || len(res.PlatformTimes) == 0 {
+		fmt.Printf("Got %+v, expected a game using the new selectors", res)
+		t.Fail()
+	}
+	user, err := client.GetUser(context.Background(), "tiamat911")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if user.Backlog != 74 || user.Playing != 3 {
+		fmt.Printf("Got %+v, expected a user using the new selectors", user)
+		t.Fail()
+	}
+}
+
+func TestLoadSelectorsInvalidKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gohltb")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "selectors.json")
+	config := `{"user-page": {"list": ".profile_details li["}}`
+	if err := ioutil.WriteFile(file, []byte(config), 0644); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	_, err = LoadSelectors(file)
+	if err == nil || !strings.Contains(err.Error(), "user-page.list") {
+		fmt.Printf("Got %v, expected error naming user-page.list", err)
+		t.Fail()
+	}
+}
+
+func TestMatchCountLayoutLabel(t *testing.T) {
+	page := `<h3>Found 1 Games</h3><ul><li class="back_darkish"><h3><a href="game?id=1">Doom</a></h3><div class="search_list_tidbit">12 Hours</div></li></ul>`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, page)
+	}))
+	defer ts.Close()
+
+	sel := DefaultSelectors()
+	sel.MatchCount = `Showing ([0-9]+) Games`
+	_, err := NewClient(WithBaseURL(ts.URL), WithSelectors(sel)).SearchGames("doom")
+	var layoutErr *LayoutError
+	if !errors.As(err, &layoutErr) {
+		t.Fatalf("Got %v, expected LayoutError", err)
+	}
+	if len(layoutErr.Selectors) != 1 || layoutErr.Selectors[0] != "h3 (Showing N Games)" {
+		fmt.Printf("Got %v, expected the label of the new match count", layoutErr.Selectors)
+		t.Fail()
+	}
+}
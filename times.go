@@ -0,0 +1,205 @@
+package gohltb
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeUnit is the unit a completion time was displayed in on howlongtobeat.com
+type TimeUnit string
+
+const (
+	// TimeUnknown is used when no completion time has been submitted (displayed as "--")
+	TimeUnknown TimeUnit = ""
+	// TimeHours is used for completion times displayed in hours
+	TimeHours TimeUnit = "hours"
+	// TimeMinutes is used for completion times displayed in minutes
+	TimeMinutes TimeUnit = "minutes"
+)
+
+// fractions maps the vulgar fractions used by the site to their decimal value
+var fractions = map[string]float64{
+	"":  0,
+	"¼": 0.25,
+	"½": 0.5,
+	"¾": 0.75,
+}
+
+// timeRegex matches completion time strings such as "12½ Hours" or "45 Mins"
+var timeRegex = regexp.MustCompile(`^([0-9]*)(¼|½|¾)?\s*(Hours?|Mins?|Minutes?)$`)
+
+// CompletionTime is the parsed representation of one of the completion times
+// found on a GameResult. The zero value represents an unknown time, which is
+// what the site displays as "--".
+type CompletionTime struct {
+	Value float64  `json:"value"` // Numeric value as displayed, i.e. 12.5 for "12½ Hours"
+	Unit  TimeUnit `json:"unit"`  // Unit the value is in, TimeUnknown if there is no time
+}
+
+// Known reports whether the completion time has a value
+func (c CompletionTime) Known() bool {
+	return c.Unit != TimeUnknown
+}
+
+// Duration converts the completion time into a time.Duration. The second
+// return value is false when the time is unknown, or too long to be held in a
+// time.Duration.
+func (c CompletionTime) Duration() (time.Duration, bool) {
+	var unit time.Duration
+	switch c.Unit {
+	case TimeHours:
+		unit = time.Hour
+	case TimeMinutes:
+		unit = time.Minute
+	default:
+		return 0, false
+	}
+	d := c.Value * float64(unit)
+	if !(d < math.MaxInt64) {
+		return 0, false
+	}
+	return time.Duration(d), true
+}
+
+// String returns the completion time in the format used by the site
+func (c CompletionTime) String() string {
+	if !c.Known() {
+		return "--"
+	}
+	whole := int(c.Value)
+	var frac string
+	for k, v := range fractions {
+		if v != 0 && v == c.Value-float64(whole) {
+			frac = k
+		}
+	}
+	unit := "Hours"
+	if c.Unit == TimeMinutes {
+		unit = "Mins"
+	}
+	if whole == 0 && frac != "" {
+		return fmt.Sprintf("%v %v", frac, unit)
+	}
+	return fmt.Sprintf("%v%v %v", whole, frac, unit)
+}
+
+// ParseCompletionTime parses a completion time string as it is displayed on
+// howlongtobeat.com (i.e. "12½ Hours", "45 Mins"). The placeholder "--", or an
+// empty string, will return an unknown CompletionTime without error.
+func ParseCompletionTime(s string) (CompletionTime, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "--" {
+		return CompletionTime{}, nil
+	}
+	m := timeRegex.FindStringSubmatch(s)
+	if m == nil || (m[1] == "" && m[2] == "") {
+		return CompletionTime{}, fmt.Errorf("unable to parse completion time %q", s)
+	}
+
+	var value float64
+	if m[1] != "" {
+		whole, err := strconv.Atoi(m[1])
+		if err != nil {
+			return CompletionTime{}, fmt.Errorf("unable to parse completion time %q", s)
+		}
+		value = float64(whole)
+	}
+	value += fractions[m[2]]
+
+	unit := TimeHours
+	if strings.HasPrefix(m[3], "Min") {
+		unit = TimeMinutes
+	}
+	return CompletionTime{Value: value, Unit: unit}, nil
+}
+
+// shortDurationRegex matches durations such as "13h 24m", "13h" or "45m"
+var shortDurationRegex = regexp.MustCompile(`^(?:([0-9]+)h)?\s*(?:([0-9]+)m)?\s*(?:([0-9]+)s)?$`)
+
+// ParseShortDuration parses the abbreviated durations used in the tables of a
+// game's page, such as "13h 24m". The placeholder "--", or an empty string, will
+// return 0 without error.
+func ParseShortDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "--" {
+		return 0, nil
+	}
+	m := shortDurationRegex.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("unable to parse duration %q", s)
+	}
+	var d time.Duration
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		if m[i+1] == "" {
+			continue
+		}
+		v, err := strconv.ParseInt(m[i+1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("unable to parse duration %q", s)
+		}
+		if v > math.MaxInt64/int64(unit) || time.Duration(v)*unit > math.MaxInt64-d {
+			return 0, fmt.Errorf("duration %q is out of range", s)
+		}
+		d += time.Duration(v) * unit
+	}
+	return d, nil
+}
+
+// parseTimeOrUnknown parses s, treating anything unparseable as an unknown time
+func parseTimeOrUnknown(s string) CompletionTime {
+	c, err := ParseCompletionTime(s)
+	if err != nil {
+		return CompletionTime{}
+	}
+	return c
+}
+
+// MainTime returns the parsed Main completion time
+func (g *GameResult) MainTime() CompletionTime {
+	return parseTimeOrUnknown(g.Main)
+}
+
+// MainExtraTime returns the parsed MainExtra completion time
+func (g *GameResult) MainExtraTime() CompletionTime {
+	return parseTimeOrUnknown(g.MainExtra)
+}
+
+// CompletionistTime returns the parsed Completionist completion time
+func (g *GameResult) CompletionistTime() CompletionTime {
+	return parseTimeOrUnknown(g.Completionist)
+}
+
+// OtherTimes returns the parsed times held in Other, keyed by the same
+// category names. Returns nil if the game has no other times.
+func (g *GameResult) OtherTimes() map[string]CompletionTime {
+	if g.Other == nil {
+		return nil
+	}
+	times := make(map[string]CompletionTime, len(g.Other))
+	for k, v := range g.Other {
+		times[k] = parseTimeOrUnknown(v)
+	}
+	return times
+}
+
+// MainDuration returns the Main completion time as a time.Duration. The
+// second return value is false when the time is unknown.
+func (g *GameResult) MainDuration() (time.Duration, bool) {
+	return g.MainTime().Duration()
+}
+
+// MainExtraDuration returns the MainExtra completion time as a time.Duration.
+// The second return value is false when the time is unknown.
+func (g *GameResult) MainExtraDuration() (time.Duration, bool) {
+	return g.MainExtraTime().Duration()
+}
+
+// CompletionistDuration returns the Completionist completion time as a
+// time.Duration. The second return value is false when the time is unknown.
+func (g *GameResult) CompletionistDuration() (time.Duration, bool) {
+	return g.CompletionistTime().Duration()
+}
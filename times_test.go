@@ -0,0 +1,115 @@
+package gohltb
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestParseCompletionTime(t *testing.T) {
+	tests := []struct {
+		in       string
+		value    float64
+		unit     TimeUnit
+		duration time.Duration
+	}{
+		{"12½ Hours", 12.5, TimeHours, 12*time.Hour + 30*time.Minute},
+		{"13¼ Hours ", 13.25, TimeHours, 13*time.Hour + 15*time.Minute},
+		{"1 Hour", 1, TimeHours, time.Hour},
+		{"45 Mins", 45, TimeMinutes, 45 * time.Minute},
+		{"½ Hours", 0.5, TimeHours, 30 * time.Minute},
+		{"--", 0, TimeUnknown, 0},
+		{"", 0, TimeUnknown, 0},
+	}
+	for _, tt := range tests {
+		c, err := ParseCompletionTime(tt.in)
+		if err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
+		if c.Value != tt.value || c.Unit != tt.unit {
+			fmt.Printf("Got %v %v, expected %v %v\n", c.Value, c.Unit, tt.value, tt.unit)
+			t.Fail()
+		}
+		d, ok := c.Duration()
+		if d != tt.duration || ok != (tt.unit != TimeUnknown) {
+			fmt.Printf("Got %v, expected %v\n", d, tt.duration)
+			t.Fail()
+		}
+	}
+}
+
+func TestParseInvalidCompletionTime(t *testing.T) {
+	for _, in := range []string{"Hours", "12 Days", "abc"} {
+		if _, err := ParseCompletionTime(in); err == nil {
+			fmt.Printf("Expected error for %q\n", in)
+			t.Fail()
+		}
+	}
+}
+
+func TestGameResultTimes(t *testing.T) {
+	res, err := makeGameCall("testdata/games/userstats.html", &HLTBQuery{Page: 2, Modifier: "user_stats"})
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	d, ok := res.Games[0].MainDuration()
+	if !ok || d != 13*time.Hour+30*time.Minute {
+		fmt.Printf("Got %v, expected 13h30m", d)
+		t.Fail()
+	}
+
+	res, err = makeGameCall("testdata/games/non_standard.html", &HLTBQuery{Query: "idarb"})
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	other := res.Games[0].OtherTimes()
+	if other["Co-Op"].Known() {
+		fmt.Printf("Got %v, expected unknown", other["Co-Op"])
+		t.Fail()
+	}
+	if other["Vs."].String() != "2½ Hours" {
+		fmt.Printf("Got %v, expected 2½ Hours", other["Vs."])
+		t.Fail()
+	}
+	if _, ok := res.Games[0].MainDuration(); ok {
+		fmt.Println("Expected unknown Main time")
+		t.Fail()
+	}
+}
+
+func TestParseShortDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"13h 24m":  13*time.Hour + 24*time.Minute,
+		"13h":      13 * time.Hour,
+		"45m":      45 * time.Minute,
+		"1h 2m 3s": time.Hour + 2*time.Minute + 3*time.Second,
+		"--":       0,
+	}
+	for in, expected := range tests {
+		d, err := ParseShortDuration(in)
+		if err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
+		if d != expected {
+			fmt.Printf("Got %v, expected %v\n", d, expected)
+			t.Fail()
+		}
+	}
+	for _, in := range []string{"13 Hours", "99999999999h", "2562047h 60m"} {
+		if _, err := ParseShortDuration(in); err == nil {
+			fmt.Printf("Expected error for %q\n", in)
+			t.Fail()
+		}
+	}
+}
+
+func TestCompletionTimeOverflow(t *testing.T) {
+	c, err := ParseCompletionTime("99999999999 Hours")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if d, ok := c.Duration(); ok {
+		fmt.Printf("Got %v, expected the time to be out of range", d)
+		t.Fail()
+	}
+}
@@ -0,0 +1,165 @@
+package gohltb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// UserList is one of the game lists kept by a user on howlongtobeat.com
+type UserList string
+
+const (
+	// ListPlaying are the games the user is currently playing
+	ListPlaying UserList = "playing"
+	// ListBacklog are the games in the user's backlog
+	ListBacklog UserList = "backlog"
+	// ListReplays are the games the user wants to replay
+	ListReplays UserList = "replays"
+	// ListCustom are the games in the user's custom list
+	ListCustom UserList = "custom"
+	// ListCompleted are the games the user has completed
+	ListCompleted UserList = "completed"
+	// ListRetired are the games the user did not finish
+	ListRetired UserList = "retired"
+)
+
+// UserGameEntry is a single game in one of a user's lists. GameID matches the
+// ID of a GameResult, and can be used with GetGame. Times are those recorded by
+// the user, and are 0 when the user has not recorded a time.
+type UserGameEntry struct {
+	GameID        string        `json:"game-id"`             // ID of the game in howlongtobeat.com's database
+	Title         string        `json:"title"`               // Title of game
+	URL           string        `json:"url"`                 // Link to game page
+	Platform      Platform      `json:"platform,omitempty"`  // Platform the user played the game on
+	Main          time.Duration `json:"main"`                // User's Main Story time
+	MainExtra     time.Duration `json:"main-extra"`          // User's Main + Extras time
+	Completionist time.Duration `json:"completionist"`       // User's Completionist time
+	Completed     string        `json:"completed,omitempty"` // Date the user completed the game, as displayed on the site
+}
+
+// UserGamesPage is a page of games from one of a user's lists. Provides ability
+// to move between pages of the list.
+type UserGamesPage struct {
+	Games       []*UserGameEntry `json:"games"`       // Slice of UserGameEntry
+	User        string           `json:"user"`        // User the list belongs to
+	List        UserList         `json:"list"`        // List the games are from
+	TotalPages  int              `json:"total-pages"` // Total number of pages
+	CurrentPage int              `json:"curent-page"` // Current page number
+	NextPage    int              `json:"next-page"`   // Next page number
+	hltbClient  *HLTBClient      // Client that was used for the initial request, needed for retrieving later pages
+}
+
+// HasNext will check to see if there is a next page that can be retrieved
+func (u *UserGamesPage) HasNext() bool {
+	return u.NextPage != 0
+}
+
+// GetNextPage will return the next page, if it exists. Uses the client
+// from the initial request to make additional queries.
+func (u *UserGamesPage) GetNextPage() (*UserGamesPage, error) {
+	return u.GetNextPageContext(context.Background())
+}
+
+// GetNextPageContext is the same as GetNextPage, but the request is bound
+// to the provided context.
+func (u *UserGamesPage) GetNextPageContext(ctx context.Context) (*UserGamesPage, error) {
+	if !u.HasNext() {
+		return &UserGamesPage{}, ErrNoMorePages
+	}
+	return u.hltbClient.GetUserGames(ctx, u.User, u.List, u.NextPage)
+}
+
+// JSON will convert the user's games into a json string
+func (u *UserGamesPage) JSON() (string, error) {
+	var r string
+	s, err := json.MarshalIndent(u.Games, "", "  ")
+	if err == nil {
+		r = string(s)
+	}
+	return r, err
+}
+
+// GetUserGames retrieves a page of the games in one of a user's lists. The
+// name is the ID found on a UserResult or UserProfile. A page of 0 will
+// retrieve the first page.
+func (h *HLTBClient) GetUserGames(ctx context.Context, name string, list UserList, page int) (*UserGamesPage, error) {
+	if name == "" {
+		return nil, errors.New("A user name is required")
+	}
+	if page == 0 {
+		page = 1
+	}
+	res, err := h.backend().GetUserGames(ctx, name, list, page)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, ErrNilPage
+	}
+	res.User = name
+	res.List = list
+	res.CurrentPage = page
+	res.hltbClient = h
+	return res, nil
+}
+
+// parseUserGames parses a page of a user's list into a UserGamesPage object
+func parseUserGames(doc *goquery.Document, page int, sel *Selectors) (*UserGamesPage, error) {
+	cols := sel.UserGames
+	table := doc.Find(cols.Table)
+	if table.Length() == 0 {
+		return nil, &ParseError{Selector: cols.Table, Page: page}
+	}
+	games := &UserGamesPage{
+		CurrentPage: page,
+		TotalPages:  parseTotalPages(doc, sel),
+	}
+	if games.TotalPages > games.CurrentPage {
+		games.NextPage = games.CurrentPage + 1
+	}
+
+	var headers []string
+	table.Find(cols.Header).Each(func(i int, cell *goquery.Selection) {
+		headers = append(headers, strings.TrimSpace(cell.Text()))
+	})
+
+	// Handle each game, matching the columns by their header
+	table.Find(cols.Row).Each(func(gameCount int, row *goquery.Selection) {
+		entry := &UserGameEntry{}
+		row.Find(cols.Cell).Each(func(i int, cell *goquery.Selection) {
+			if i >= len(headers) {
+				return
+			}
+			value := strings.TrimSpace(cell.Text())
+			d, _ := ParseShortDuration(value)
+			switch headers[i] {
+			case cols.Title:
+				link := cell.Find(cols.TitleLink).First()
+				href, _ := link.Attr("href")
+				entry.Title = sanitizeTitle(link.Text())
+				entry.URL = urlPrefix + href
+				entry.GameID, _ = parseID(href, sel.GameLink)
+			case cols.Platform:
+				entry.Platform, _ = ParsePlatform(value)
+			case cols.Main:
+				entry.Main = d
+			case cols.MainExtra:
+				entry.MainExtra = d
+			case cols.Completionist:
+				entry.Completionist = d
+			case cols.Completed:
+				if value != "--" {
+					entry.Completed = value
+				}
+			}
+		})
+		games.Games = append(games.Games, entry)
+	})
+
+	return games, nil
+}
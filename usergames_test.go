@@ -0,0 +1,106 @@
+package gohltb
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUserGamesParse(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/users/games.html")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	var lastPage string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user_games" || r.URL.Query().Get("s") != "completed" {
+			w.WriteHeader(404)
+			return
+		}
+		lastPage = r.URL.Query().Get("page")
+		fmt.Fprintln(w, string(data))
+	}))
+	defer ts.Close()
+	client := NewCustomClient(&HTTPClient{baseURL: ts.URL})
+
+	res, err := client.GetUserGames(context.Background(), "tiamat911", ListCompleted, 0)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(res.Games) != 2 {
+		t.Fatalf("Got %v, expected 2", len(res.Games))
+	}
+	doom := res.Games[0]
+	if doom.GameID != "57506" || doom.Platform != PC || doom.Main != 14*time.Hour+30*time.Minute || doom.Completed != "April 2nd, 2020" {
+		fmt.Printf("Got %+v, unexpected entry", doom)
+		t.Fail()
+	}
+	fallout := res.Games[1]
+	if fallout.Title != "Fallout 4" || fallout.Platform != PlayStation4 || fallout.Completed != "" || fallout.MainExtra != 95*time.Hour+12*time.Minute {
+		fmt.Printf("Got %+v, unexpected entry", fallout)
+		t.Fail()
+	}
+	if res.TotalPages != 9 || res.NextPage != 2 {
+		fmt.Printf("Got %v/%v, expected 9 pages with next page 2", res.TotalPages, res.NextPage)
+		t.Fail()
+	}
+
+	res, err = res.GetNextPage()
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if lastPage != "2" || res.CurrentPage != 2 || res.List != ListCompleted {
+		fmt.Printf("Got page %v, expected 2", lastPage)
+		t.Fail()
+	}
+	res.JSON()
+}
+
+func TestEmptyUserGames(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/users/games_empty.html")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, string(data))
+	}))
+	defer ts.Close()
+	client := NewCustomClient(&HTTPClient{baseURL: ts.URL})
+
+	res, err := client.GetUserGames(context.Background(), "tiamat911", ListRetired, 1)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(res.Games) != 0 || res.HasNext() {
+		fmt.Printf("Got %v games, expected 0 and no next page", len(res.Games))
+		t.Fail()
+	}
+}
+
+func TestUserGamesPageLinkArgs(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/users/games.html")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	// Only the third argument of userGames is the page
+	page := strings.Replace(string(data), "userGames('tiamat911','completed','9')", "userGames('tiamat911','completed','9','50')", 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, page)
+	}))
+	defer ts.Close()
+	client := NewCustomClient(&HTTPClient{baseURL: ts.URL})
+
+	res, err := client.GetUserGames(context.Background(), "tiamat911", ListCompleted, 1)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if res.TotalPages != 9 {
+		fmt.Printf("Got %v, expected 9", res.TotalPages)
+		t.Fail()
+	}
+}
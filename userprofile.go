@@ -0,0 +1,134 @@
+package gohltb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ordinalRegex matches the ordinal suffix of a day, i.e. the "th" in "6th"
+var ordinalRegex = regexp.MustCompile(`([0-9]+)(st|nd|rd|th)`)
+
+// UserProfile is the data object for a user's page on howlongtobeat.com. It has
+// the details displayed on the user's profile, which are more than what's shown
+// in a UserResult. Counts are the number of games in each of the user's lists.
+type UserProfile struct {
+	ID        string   `json:"id"`                  // ID in howlongtobeat.com's database
+	Name      string   `json:"name"`                // User's display name
+	URL       string   `json:"url"`                 // Link to user's page
+	AvatarURL string   `json:"avatar-url"`          // Link to avatar
+	Location  string   `json:"location,omitempty"`  // User's location
+	Bio       string   `json:"bio,omitempty"`       // User's bio
+	JoinDate  string   `json:"join-date,omitempty"` // Date the user joined, as displayed on the site
+	Playtime  string   `json:"playtime,omitempty"`  // Total time the user has logged, as displayed on the site
+	Playing   int      `json:"playing"`             // Number of games the user is playing
+	Backlog   int      `json:"backlog"`             // Number of games in the user's backlog
+	Replays   int      `json:"replays"`             // Number of games the user wants to replay
+	Custom    int      `json:"custom"`              // Number of games in the user's custom list
+	Completed int      `json:"completed"`           // Number of games the user has completed
+	Retired   int      `json:"retired"`             // Number of games the user did not finish
+	Accolades []string `json:"accolades,omitempty"` // User accolades for activity on the site. This is things like years of service.
+}
+
+// JSON will convert a user profile object into a json string
+func (u *UserProfile) JSON() (string, error) {
+	var r string
+	s, err := json.MarshalIndent(u, "", "  ")
+	if err == nil {
+		r = string(s)
+	}
+	return r, err
+}
+
+// JoinTime parses JoinDate, i.e. "February 6th, 2014", into a time.Time
+func (u *UserProfile) JoinTime() (time.Time, error) {
+	return time.Parse("January 2, 2006", ordinalRegex.ReplaceAllString(u.JoinDate, "$1"))
+}
+
+// GetUser retrieves the profile of the user with the provided name, which is
+// the ID found on a UserResult.
+func (h *HLTBClient) GetUser(ctx context.Context, name string) (*UserProfile, error) {
+	if name == "" {
+		return nil, errors.New("A user name is required")
+	}
+	user, err := h.backend().GetUser(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrNilPage
+	}
+	return user, nil
+}
+
+// parseUserProfile parses a user's page into a UserProfile object
+func parseUserProfile(doc *goquery.Document, id string, sel *Selectors) (*UserProfile, error) {
+	page := sel.UserPage
+	labels := page.Labels
+	header := doc.Find(page.Name).First()
+	if header.Length() == 0 {
+		return nil, &ParseError{Selector: page.Name}
+	}
+	avatar, _ := doc.Find(page.Avatar).Attr("src")
+
+	// The header holds both the name and the accolades, so drop the accolades
+	// to get at the name
+	name := header.Clone()
+	name.Find(page.Accolades).Remove()
+
+	user := &UserProfile{
+		ID:        id,
+		URL:       urlPrefix + sel.UserLink + url.QueryEscape(id),
+		AvatarURL: urlPrefix + avatar,
+		Name:      sanitizeTitle(name.Text()),
+		Accolades: parseAccolades(header),
+	}
+
+	// Handle the count of games in each of the user's lists
+	doc.Find(page.List).Each(func(listCount int, list *goquery.Selection) {
+		count, err := ParseCount(list.Find(page.ListCount).First().Text())
+		if err != nil {
+			return
+		}
+		switch strings.TrimSpace(list.Find(page.ListLabel).Text()) {
+		case labels.Playing:
+			user.Playing = count
+		case labels.Backlog:
+			user.Backlog = count
+		case labels.Replays:
+			user.Replays = count
+		case labels.Custom:
+			user.Custom = count
+		case labels.Completed:
+			user.Completed = count
+		case labels.Retired:
+			user.Retired = count
+		}
+	})
+
+	// Handle the user's profile details, each of which is a label followed by its value
+	doc.Find(page.Info).Each(func(infoCount int, info *goquery.Selection) {
+		if info.Is(page.Bio) {
+			user.Bio = sanitizeTitle(info.Text())
+			return
+		}
+		label := info.Find(page.InfoLabel).First()
+		value := sanitizeTitle(strings.Replace(info.Text(), label.Text(), "", 1))
+		switch strings.TrimSuffix(strings.TrimSpace(label.Text()), ":") {
+		case labels.Location:
+			user.Location = value
+		case labels.Joined:
+			user.JoinDate = value
+		case labels.Playtime:
+			user.Playtime = value
+		}
+	})
+
+	return user, nil
+}
@@ -0,0 +1,72 @@
+package gohltb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func makeUserProfileCall(file string, name string) (*UserProfile, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" || r.URL.Query().Get("n") != name {
+			w.WriteHeader(404)
+			return
+		}
+		fmt.Fprintln(w, string(data))
+	}))
+	defer ts.Close()
+
+	client := NewCustomClient(&HTTPClient{baseURL: ts.URL})
+	return client.GetUser(context.Background(), name)
+}
+
+func TestUserProfileParse(t *testing.T) {
+	user, err := makeUserProfileCall("testdata/users/profile.html", "tiamat911")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if user.Name != "tiamat911" {
+		fmt.Printf("Got %v, expected tiamat911", user.Name)
+		t.Fail()
+	}
+	if len(user.Accolades) != 4 || user.Accolades[0] != "6 Year(s)" {
+		fmt.Printf("Got %v, expected 4 accolades", user.Accolades)
+		t.Fail()
+	}
+	if user.Playing != 3 || user.Backlog != 74 || user.Replays != 2 || user.Custom != 1024 ||
+		user.Completed != 169 || user.Retired != 5 {
+		fmt.Printf("Got %+v, unexpected list counts", user)
+		t.Fail()
+	}
+	if user.Playtime != "3,587 Hours" || user.Location != "Quebec, Canada" || user.Bio == "" {
+		fmt.Printf("Got %+v, unexpected profile details", user)
+		t.Fail()
+	}
+	joined, err := user.JoinTime()
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if !joined.Equal(time.Date(2014, time.February, 6, 0, 0, 0, 0, time.UTC)) {
+		fmt.Printf("Got %v, expected 2014-02-06", joined)
+		t.Fail()
+	}
+	user.JSON()
+}
+
+func TestUserProfileNotFound(t *testing.T) {
+	_, err := makeUserProfileCall("testdata/users/notfound.html", "nobody")
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		fmt.Printf("Got %v, expected ParseError", err)
+		t.Fail()
+	}
+}
@@ -1,6 +1,7 @@
 package gohltb
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"strconv"
@@ -14,17 +15,18 @@ import (
 // howlongtobeat.com that contribute completion times to the site or track their game
 // collections.
 type UserResult struct {
-	ID        string   `json:"id"`                  // ID in howlongtobeat.com's database
-	Name      string   `json:"name"`                // User's display name
-	URL       string   `json:"url"`                 // Link to user's page
-	AvatarURL string   `json:"avatar-url"`          // Link to avatar
-	Location  string   `json:"location,omitempty"`  // User's location
-	Backlog   string   `json:"backlog,omitempty"`   // Number of games in user's backlog
-	Complete  string   `json:"complete,omitempty"`  // Number of games the user's completed
-	Gender    string   `json:"gender,omitempty"`    // User's gender
-	Posts     string   `json:"posts,omitempty"`     // Number of user's forum posts
-	Age       int      `json:"age,omitempty"`       // User's age
-	Accolades []string `json:"accolades,omitempty"` // User accolades for activity on the site. This is things like years of service.
+	ID        string            `json:"id"`                  // ID in howlongtobeat.com's database
+	Name      string            `json:"name"`                // User's display name
+	URL       string            `json:"url"`                 // Link to user's page
+	AvatarURL string            `json:"avatar-url"`          // Link to avatar
+	Location  string            `json:"location,omitempty"`  // User's location
+	Backlog   string            `json:"backlog,omitempty"`   // Number of games in user's backlog
+	Complete  string            `json:"complete,omitempty"`  // Number of games the user's completed
+	Gender    string            `json:"gender,omitempty"`    // User's gender
+	Posts     string            `json:"posts,omitempty"`     // Number of user's forum posts
+	Age       int               `json:"age,omitempty"`       // User's age
+	Accolades []string          `json:"accolades,omitempty"` // User accolades for activity on the site. This is things like years of service.
+	Extra     map[string]string `json:"extra,omitempty"`     // Details with labels the library doesn't recognise, keyed by label
 }
 
 // UserResultsPage is a page of user responses. It is the data model that will be
@@ -48,12 +50,26 @@ func (u *UserResultsPage) HasNext() bool {
 // GetNextPage will return the next page, if it exists. Uses the client
 // from the initial request to make additional queries.
 func (u *UserResultsPage) GetNextPage() (*UserResultsPage, error) {
+	return u.GetNextPageContext(context.Background())
+}
+
+// GetNextPageContext is the same as GetNextPage, but the request is bound
+// to the provided context.
+func (u *UserResultsPage) GetNextPageContext(ctx context.Context) (*UserResultsPage, error) {
 	if !u.HasNext() {
-		return &UserResultsPage{}, errors.New("Page not found")
+		return &UserResultsPage{}, ErrNoMorePages
 	}
 	query := u.requestQuery
 	query.Page = u.NextPage
-	return u.hltbClient.SearchUsersByQuery(query)
+	res, err := u.hltbClient.SearchUsersByQueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	// Only the first page has the number of matches, so carry it forward
+	if res.TotalMatches == 0 {
+		res.TotalMatches = u.TotalMatches
+	}
+	return res, nil
 }
 
 // JSON will convert user object into a json string
@@ -82,7 +98,13 @@ func (u *UserResultsPage) setTotalMatches(m int) {
 //
 // Note: A query with an empty string will query ALL users.
 func (h *HLTBClient) SearchUsers(query string) (*UserResultsPage, error) {
-	return userSearch(h, &HLTBQuery{Query: query})
+	return userSearch(context.Background(), h, &HLTBQuery{Query: query})
+}
+
+// SearchUsersContext is the same as SearchUsers, but the request is bound
+// to the provided context. Cancelling the context will abort the request.
+func (h *HLTBClient) SearchUsersContext(ctx context.Context, query string) (*UserResultsPage, error) {
+	return userSearch(ctx, h, &HLTBQuery{Query: query})
 }
 
 // SearchUsersByQuery queries using a set of user defined parameters. Used
@@ -97,24 +119,34 @@ func (h *HLTBClient) SearchUsers(query string) (*UserResultsPage, error) {
 //
 // Note: A query with an empty "Query" string will query ALL users.
 func (h *HLTBClient) SearchUsersByQuery(q *HLTBQuery) (*UserResultsPage, error) {
-	return userSearch(h, q)
+	return userSearch(context.Background(), h, q)
+}
+
+// SearchUsersByQueryContext is the same as SearchUsersByQuery, but the request
+// is bound to the provided context. Cancelling the context will abort the request.
+func (h *HLTBClient) SearchUsersByQueryContext(ctx context.Context, q *HLTBQuery) (*UserResultsPage, error) {
+	return userSearch(ctx, h, q)
 }
 
 // userSearch is the central method for running user queries
-func userSearch(h *HLTBClient, q *HLTBQuery) (*UserResultsPage, error) {
+func userSearch(ctx context.Context, h *HLTBClient, q *HLTBQuery) (*UserResultsPage, error) {
 	handleUserDefaults(q)
-	doc, err := searchQuery(h, q)
+	res, err := h.backend().SearchUsers(ctx, q)
 	if err != nil {
 		return nil, err
 	}
-	if !hasResults(doc) {
-		return &UserResultsPage{}, nil
-	}
-	res, err := parseUserResponse(doc, q)
-	if err != nil {
-		return nil, err
+	if res == nil {
+		return nil, ErrNilPage
 	}
+	res.requestQuery = q
 	res.hltbClient = h
+	if h.OnUnknownLabel != nil {
+		for _, user := range res.Users {
+			for label, value := range user.Extra {
+				h.OnUnknownLabel(UserQuery, label, value)
+			}
+		}
+	}
 	return res, nil
 }
 
@@ -133,34 +165,33 @@ func handleUserDefaults(q *HLTBQuery) {
 }
 
 // parseUserResponse parses the http response object into a UserResultsPage object
-func parseUserResponse(doc *goquery.Document, q *HLTBQuery) (*UserResultsPage, error) {
+func parseUserResponse(doc *goquery.Document, q *HLTBQuery, sel *Selectors) (*UserResultsPage, error) {
 	users := &UserResultsPage{}
 	var userslice []*UserResult
 
 	// Handle the page numbers
-	parsePages(doc, users, q.Page)
+	if err := parsePages(doc, users, q.Page, sel); err != nil {
+		return nil, err
+	}
 
 	// Handle each user
-	doc.Find("ul > li.back_darkish").Each(func(userCount int, userDetails *goquery.Selection) {
+	var parseErr error
+	doc.Find(sel.Result).EachWithBreak(func(userCount int, userDetails *goquery.Selection) bool {
 		var location string
-		var accolades []string
 
-		avatar, _ := userDetails.Find("div > a > img").Attr("src")
-		name := userDetails.Find("h3 > a")
+		avatar, _ := userDetails.Find(sel.ResultImage).Attr("src")
+		name := userDetails.Find(sel.ResultLink)
 		url, _ := name.Attr("href")
-		id := strings.SplitAfter(url, "user?n=")[1]
-		if ok := userDetails.Find("h4").Length(); ok > 0 {
-			location = userDetails.Find("h4").First().Text()
+		id, ok := parseID(url, sel.UserLink)
+		if !ok {
+			parseErr = &ParseError{Selector: sel.ResultLink, Page: q.Page, Err: errors.New("user ID not found")}
+			return false
 		}
-		// Handle any user accolades - these are awards earned by users
-		if ok := userDetails.Find(".search_list_details > h3 > span").Length(); ok > 0 {
-			accoladeSpan := userDetails.Find(".search_list_details > h3 > span").First()
-			accoladeSpan.Find("span").Each(func(aCount int, accolade *goquery.Selection) {
-				if v, exists := accolade.Attr("title"); exists {
-					accolades = append(accolades, v)
-				}
-			})
+		if ok := userDetails.Find(sel.Location).Length(); ok > 0 {
+			location = userDetails.Find(sel.Location).First().Text()
 		}
+		// Handle any user accolades - these are awards earned by users
+		accolades := parseAccolades(userDetails.Find(sel.Accolades))
 
 		user := &UserResult{
 			ID:        id,
@@ -173,26 +204,35 @@ func parseUserResponse(doc *goquery.Document, q *HLTBQuery) (*UserResultsPage, e
 
 		// There are set of different options that a user can choose to enter, none of
 		// which are mandatory. This will handle each of them, if they're present.
-		userDetails.Find(".search_list_tidbit.text_white").Each(func(userDetailCount int, userDetail *goquery.Selection) {
+		userDetails.Find(sel.Label).Each(func(userDetailCount int, userDetail *goquery.Selection) {
 			nextValue := strings.TrimSpace(userDetail.Next().Text())
 			switch category := userDetail.Text(); {
-			case category == "Backlog":
+			case category == sel.UserLabels.Backlog:
 				user.Backlog = nextValue
-			case category == "Complete":
+			case category == sel.UserLabels.Complete:
 				user.Complete = nextValue
-			case category == "Gender":
+			case category == sel.UserLabels.Gender:
 				user.Gender = nextValue
-			case category == "Age":
+			case category == sel.UserLabels.Age:
 				if i, err := strconv.Atoi(nextValue); err == nil {
 					user.Age = i
 				}
-			case category == "Posts":
+			case category == sel.UserLabels.Posts:
 				user.Posts = nextValue
+			default:
+				if user.Extra == nil {
+					user.Extra = make(map[string]string)
+				}
+				user.Extra[category] = nextValue
 			}
 		})
 
 		userslice = append(userslice, user)
+		return true
 	})
+	if parseErr != nil {
+		return nil, parseErr
+	}
 	users.Users = userslice
 	users.CurrentPage = q.Page
 	users.requestQuery = q
@@ -203,3 +243,19 @@ func parseUserResponse(doc *goquery.Document, q *HLTBQuery) (*UserResultsPage, e
 	return users, nil
 
 }
+
+// parseAccolades collects the accolades displayed next to a user's name. The
+// header is the element containing the user's name, whose span holds a titled
+// span for each accolade.
+func parseAccolades(header *goquery.Selection) []string {
+	var accolades []string
+	if ok := header.ChildrenFiltered("span").Length(); ok > 0 {
+		accoladeSpan := header.ChildrenFiltered("span").First()
+		accoladeSpan.Find("span").Each(func(aCount int, accolade *goquery.Selection) {
+			if v, exists := accolade.Attr("title"); exists {
+				accolades = append(accolades, v)
+			}
+		})
+	}
+	return accolades
+}
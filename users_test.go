@@ -1,10 +1,13 @@
 package gohltb
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -119,8 +122,8 @@ func TestMultipleUserPages(t *testing.T) {
 		fmt.Printf("Got %v, expected 3", res.NextPage)
 		t.Fail()
 	}
-	if res.TotalMatches != 0 {
-		fmt.Printf("Got %v, expected 0", res.TotalMatches)
+	if res.TotalMatches != 255629 {
+		fmt.Printf("Got %v, expected 255629", res.TotalMatches)
 		t.Fail()
 	}
 	if res.TotalPages != 12782 {
@@ -167,8 +170,9 @@ func TestNon200UserResponse(t *testing.T) {
 	client := NewCustomClient(&HTTPClient{baseURL: mockURL})
 
 	resp, err := client.SearchUsersByQuery(&HLTBQuery{Query: "bugsnax"})
-	if err.Error() != "Error retrieving data" {
-		fmt.Printf("Got %v, expected Error retrieving data", err.Error())
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != 400 {
+		fmt.Printf("Got %v, expected HTTPStatusError with status 400", err)
 		t.Fail()
 	}
 	if resp != nil {
@@ -176,3 +180,54 @@ func TestNon200UserResponse(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestCancelledUserContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Request should not have been sent")
+	}))
+	defer ts.Close()
+
+	mockURL := ts.URL
+	client := NewCustomClient(&HTTPClient{baseURL: mockURL})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resp, err := client.SearchUsersContext(ctx, "bugsnax")
+	if err == nil {
+		t.Fatal("Expected error for cancelled context")
+	}
+	if resp != nil {
+		fmt.Println("Expected nil object")
+		t.Fail()
+	}
+}
+
+func TestUnknownUserLabels(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/users/mixed_response.html")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	page := strings.ReplaceAll(string(data), ">Gender<", ">Pronouns<")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, page)
+	}))
+	defer ts.Close()
+
+	var queryTypes []QueryType
+	client := NewClient(WithBaseURL(ts.URL), WithUnknownLabelHandler(func(queryType QueryType, label, value string) {
+		queryTypes = append(queryTypes, queryType)
+	}))
+	res, err := client.SearchUsers("tiamat")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	user := res.Users[0]
+	if user.Gender != "" || user.Extra["Pronouns"] != "Male" {
+		fmt.Printf("Got %+v, expected unknown label in Extra", user)
+		t.Fail()
+	}
+	if len(queryTypes) == 0 || queryTypes[0] != UserQuery {
+		fmt.Printf("Got %v, expected unknown labels reported", queryTypes)
+		t.Fail()
+	}
+}